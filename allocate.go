@@ -0,0 +1,100 @@
+package decimal
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Allocate distributes total across parts proportional to the ratios, each
+// part rounded to the given number of places, so that the parts sum up exactly
+// to total.
+//
+// Allocate uses the largest remainder method: each part is first truncated,
+// then the units of 10^-places left over are given one by one to the parts with
+// the largest truncated remainders. Parts with equal remainders are served in
+// the order of the ratios, so that the result is deterministic. A negative
+// total is allocated like its absolute value, with the parts negated.
+//
+// Allocate returns an error if ratios is empty, if a ratio is negative or all
+// of them are zero, or if total has more than places decimal places or isn't
+// finite.
+//
+// Example:
+//
+//	parts, err := Allocate(NewFromInt(100), []Decimal{NewFromInt(1), NewFromInt(1), NewFromInt(1)}, 2)
+//	// output: [33.34 33.33 33.33]
+//
+//	parts, err := Allocate(NewFromFloat(0.05), []Decimal{NewFromInt(3), NewFromInt(7)}, 2)
+//	// output: [0.02 0.03]
+func Allocate(total Decimal, ratios []Decimal, places int32) ([]Decimal, error) {
+	if len(ratios) == 0 {
+		return nil, fmt.Errorf("cannot allocate %s without ratios", total)
+	}
+	if total.form != formFinite {
+		return nil, fmt.Errorf("cannot allocate %s", total)
+	}
+	if !total.Round(places).Equal(total) {
+		return nil, fmt.Errorf("cannot allocate %s with %d decimal places", total, places)
+	}
+	sum := New(0, 0)
+	for _, r := range ratios {
+		if r.form != formFinite || r.Sign() < 0 {
+			return nil, fmt.Errorf("cannot allocate with ratio %s", r)
+		}
+		sum = sum.Add(r)
+	}
+	if sum.Sign() == 0 {
+		return nil, fmt.Errorf("cannot allocate with ratios summing up to zero")
+	}
+
+	// truncate the parts of abs(total), whose remainders all have the divisor
+	// sum, so that they compare like the discarded fractions of the parts
+	abs := total.Abs()
+	parts := make([]Decimal, len(ratios))
+	rems := make([]Decimal, len(ratios))
+	allocated := New(0, 0)
+	for i, r := range ratios {
+		parts[i], rems[i] = abs.Mul(r).QuoRem(sum, places)
+		allocated = allocated.Add(parts[i])
+	}
+
+	// the left over units are fewer than the parts
+	order := make([]int, len(ratios))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return rems[order[i]].Cmp(rems[order[j]]) > 0
+	})
+	unit := New(1, -places)
+	left := abs.Sub(allocated).Shift(places).IntPart()
+	for _, i := range order[:left] {
+		parts[i] = parts[i].Add(unit)
+	}
+
+	if total.Sign() < 0 {
+		for i := range parts {
+			parts[i] = parts[i].Neg()
+		}
+	}
+	return parts, nil
+}
+
+// Split distributes total into n parts as equal as possible, each rounded to
+// the given number of places, so that the parts sum up exactly to total. The
+// first parts are the larger ones in absolute value, see Allocate.
+//
+// Example:
+//
+//	parts, err := Split(NewFromInt(100), 3, 2)
+//	// output: [33.34 33.33 33.33]
+func Split(total Decimal, n int, places int32) ([]Decimal, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("cannot split %s into %d parts", total, n)
+	}
+	ratios := make([]Decimal, n)
+	for i := range ratios {
+		ratios[i] = New(1, 0)
+	}
+	return Allocate(total, ratios, places)
+}
@@ -0,0 +1,91 @@
+package decimal
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		total  string
+		ratios []string
+		places int32
+		want   string
+	}{
+		{"100", []string{"1", "1", "1"}, 2, "[33.34 33.33 33.33]"},
+		{"-100", []string{"1", "1", "1"}, 2, "[-33.34 -33.33 -33.33]"},
+		{"0.05", []string{"3", "7"}, 2, "[0.02 0.03]"},
+		{"0.05", []string{"7", "3"}, 2, "[0.04 0.01]"},
+		{"10", []string{"0.5", "0.25", "0.25"}, 0, "[5 3 2]"},
+		{"1", []string{"1", "0", "2"}, 2, "[0.33 0 0.67]"},
+		{"0.07", []string{"1", "1", "1", "1"}, 2, "[0.02 0.02 0.02 0.01]"},
+		{"1234.56", []string{"0.2", "0.3", "0.5"}, 2, "[246.91 370.37 617.28]"},
+		{"1000", []string{"1", "2", "3"}, -2, "[200 300 500]"},
+		{"0", []string{"1", "2"}, 2, "[0 0]"},
+		{"99999999999999999999.99", []string{"1", "1", "1"}, 2, "[33333333333333333333.33 33333333333333333333.33 33333333333333333333.33]"},
+		{"5", []string{"1"}, 1, "[5]"},
+	}
+	for _, test := range tests {
+		total := RequireFromString(test.total)
+		ratios := make([]Decimal, len(test.ratios))
+		for i, r := range test.ratios {
+			ratios[i] = RequireFromString(r)
+		}
+		parts, err := Allocate(total, ratios, test.places)
+		if err != nil {
+			t.Errorf("unexpected error allocating %s by %v: %v", total, ratios, err)
+			continue
+		}
+		if got := fmt.Sprint(parts); got != test.want {
+			t.Errorf("expected %s allocating %s by %v, got %s", test.want, total, ratios, got)
+		}
+		if sum := Sum(parts[0], parts[1:]...); !sum.Equal(total) {
+			t.Errorf("expected parts summing up to %s, got %s", total, sum)
+		}
+	}
+}
+
+func TestAllocateErrors(t *testing.T) {
+	tests := []struct {
+		total  Decimal
+		ratios []Decimal
+		places int32
+	}{
+		{New(100, 0), nil, 2},
+		{New(100, 0), []Decimal{New(1, 0), New(-1, 0)}, 2},
+		{New(100, 0), []Decimal{Zero, Zero}, 2},
+		{New(1005, -3), []Decimal{New(1, 0)}, 2},
+		{NaN(), []Decimal{New(1, 0)}, 2},
+		{New(1, 0), []Decimal{Inf(1)}, 2},
+	}
+	for _, test := range tests {
+		if parts, err := Allocate(test.total, test.ratios, test.places); err == nil {
+			t.Errorf("expected error allocating %s by %v, got %v", test.total, test.ratios, parts)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		total  string
+		n      int
+		places int32
+		want   string
+	}{
+		{"100", 3, 2, "[33.34 33.33 33.33]"},
+		{"-0.1", 3, 2, "[-0.04 -0.03 -0.03]"},
+		{"10", 4, 0, "[3 3 2 2]"},
+		{"1", 1, 0, "[1]"},
+		{"0.01", 3, 2, "[0.01 0 0]"},
+	}
+	for _, test := range tests {
+		total := RequireFromString(test.total)
+		parts, err := Split(total, test.n, test.places)
+		if err != nil || fmt.Sprint(parts) != test.want {
+			t.Errorf("expected %s splitting %s into %d, got %v and %v", test.want, total, test.n, parts, err)
+		}
+	}
+	if _, err := Split(New(1, 0), 0, 2); err == nil {
+		t.Errorf("expected error splitting into 0 parts")
+	}
+}
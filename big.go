@@ -0,0 +1,177 @@
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Big is a mutable decimal number, meant for hot loops where allocating a new
+// Decimal for every intermediate result is too expensive, e.g. summing millions
+// of amounts. Big reuses its internal big.Int storage, so once it has grown to
+// the size of the numbers involved, Add, Sub and Mul don't allocate anymore.
+//
+// Like the types of math/big, operations set the receiver z to the result and
+// return z, which may be one of the operands:
+//
+//	var sum, amount decimal.Big
+//	for _, d := range amounts {
+//		sum.Add(&sum, amount.Set(d))
+//	}
+//	total := sum.Decimal()
+//
+// The zero value of a Big is 0 and is ready to use. A Big can't hold NaN or
+// infinities. Big values must not be copied, use pointers to Big instead.
+// Decimal remains the type to store and exchange numbers.
+type Big struct {
+	value big.Int
+	exp   int32
+
+	// scratch storage, kept across operations to avoid allocations
+	tmp, pow, rem big.Int
+}
+
+// pow10Uint64 are the powers of ten that fit into an uint64.
+var pow10Uint64 = [...]uint64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19,
+}
+
+// pow10 sets the scratch storage z.pow to 10^n and returns it.
+func (z *Big) pow10(n int64) *big.Int {
+	if n < int64(len(pow10Uint64)) {
+		return z.pow.SetUint64(pow10Uint64[n])
+	}
+	return z.pow.Exp(tenInt, big.NewInt(n), nil)
+}
+
+// swapTmp moves the result computed in the scratch storage z.tmp to z.value.
+// Computing results in z.tmp lets math/big reuse storage even when z is also
+// an operand.
+func (z *Big) swapTmp(exp int32) *Big {
+	z.value, z.tmp = z.tmp, z.value
+	z.exp = exp
+	return z
+}
+
+// Set sets z to d and returns z. It panics if d is NaN or an infinity.
+func (z *Big) Set(d Decimal) *Big {
+	if d.form != formFinite {
+		panic(fmt.Sprintf("Cannot represent %s as a Big", d.String()))
+	}
+	if d.value == nil {
+		z.value.SetInt64(d.compact)
+	} else {
+		z.value.Set(d.value)
+	}
+	z.exp = d.exp
+	return z
+}
+
+// Decimal returns the value of z as a new Decimal.
+func (z *Big) Decimal() Decimal {
+	if z.value.IsInt64() {
+		return New(z.value.Int64(), z.exp)
+	}
+	return Decimal{value: new(big.Int).Set(&z.value), exp: z.exp}
+}
+
+// String returns the string representation of z, like Decimal.String.
+func (z *Big) String() string {
+	d := Decimal{value: &z.value, exp: z.exp}
+	return d.String()
+}
+
+// Sign returns -1, 0 or +1 depending on the sign of z.
+func (z *Big) Sign() int {
+	return z.value.Sign()
+}
+
+// Cmp compares z and y and returns -1, 0 or +1 like Decimal.Cmp.
+func (z *Big) Cmp(y *Big) int {
+	x := Decimal{value: &z.value, exp: z.exp}
+	return x.Cmp(Decimal{value: &y.value, exp: y.exp})
+}
+
+// Add sets z to x + y and returns z.
+func (z *Big) Add(x, y *Big) *Big {
+	return z.add(x, y, false)
+}
+
+// Sub sets z to x - y and returns z.
+func (z *Big) Sub(x, y *Big) *Big {
+	return z.add(x, y, true)
+}
+
+// add sets z to x + y, or x - y if sub is set, with the exponent of the
+// operand that has the most decimal places.
+func (z *Big) add(x, y *Big, sub bool) *Big {
+	a, b, exp := &x.value, &y.value, x.exp
+	if x.exp > y.exp {
+		a, exp = z.rem.Mul(a, z.pow10(int64(x.exp)-int64(y.exp))), y.exp
+	} else if x.exp < y.exp {
+		b = z.rem.Mul(b, z.pow10(int64(y.exp)-int64(x.exp)))
+	}
+
+	if sub {
+		z.tmp.Sub(a, b)
+	} else {
+		z.tmp.Add(a, b)
+	}
+	return z.swapTmp(exp)
+}
+
+// Mul sets z to x * y and returns z.
+// It panics if the exponent of the product overflows an int32, like Decimal.Mul.
+func (z *Big) Mul(x, y *Big) *Big {
+	expInt64 := int64(x.exp) + int64(y.exp)
+	if expInt64 > math.MaxInt32 || expInt64 < math.MinInt32 {
+		panic(fmt.Sprintf("exponent %v overflows an int32!", expInt64))
+	}
+
+	z.tmp.Mul(&x.value, &y.value)
+	return z.swapTmp(int32(expInt64))
+}
+
+// Quo sets z to x / y rounded half up to prec decimal places, like
+// Decimal.DivRound, and returns z. It panics if y is zero.
+func (z *Big) Quo(x, y *Big, prec int32) *Big {
+	if y.value.Sign() == 0 {
+		panic("decimal division by 0")
+	}
+
+	// bring the operands to the scale of the quotient, i.e. a / b has exponent -prec
+	e := int64(x.exp) - int64(y.exp) + int64(prec)
+	if e > math.MaxInt32 || e < math.MinInt32 {
+		panic("overflow in decimal QuoRem")
+	}
+	a, b := &x.value, &y.value
+	if e >= 0 {
+		a = z.rem.Mul(a, z.pow10(e))
+	} else {
+		b = z.rem.Mul(b, z.pow10(-e))
+	}
+
+	z.tmp.QuoRem(a, b, &z.pow)
+	neg := x.value.Sign() != y.value.Sign()
+	RoundHalfUp.roundQuo(&z.tmp, &z.pow, b, neg)
+	return z.swapTmp(-prec)
+}
+
+// Round sets z to x rounded to places decimal places using the rounding mode,
+// and returns z. Like Decimal.RoundMode, the result always has exactly places
+// decimal places. If places < 0, x is rounded to a multiple of 10^(-places).
+func (z *Big) Round(x *Big, places int32, mode RoundingMode) *Big {
+	diff := -int64(places) - int64(x.exp)
+	switch {
+	case diff == 0:
+		z.tmp.Set(&x.value)
+	case diff < 0:
+		z.tmp.Mul(&x.value, z.pow10(-diff))
+	default:
+		b := z.pow10(diff)
+		z.tmp.QuoRem(&x.value, b, &z.rem)
+		mode.roundQuo(&z.tmp, &z.rem, b, x.value.Sign() < 0)
+	}
+	return z.swapTmp(-places)
+}
@@ -0,0 +1,110 @@
+package decimal
+
+import (
+	"testing"
+)
+
+var bigTestInputs = []string{
+	"0", "1", "-1", "0.1", "-0.25", "3.14159", "123456789012345678901234567890.123",
+	"-98765.4321", "1e5", "-7e-12", "0.000", "999999999999999999999",
+}
+
+func TestBig_Arithmetic(t *testing.T) {
+	for _, s := range bigTestInputs {
+		for _, s2 := range bigTestInputs {
+			d, d2 := RequireFromString(s), RequireFromString(s2)
+			var x, y, z Big
+			x.Set(d)
+			y.Set(d2)
+
+			if got, want := z.Add(&x, &y).Decimal(), d.Add(d2); !got.Equal(want) || got.exp != want.exp {
+				t.Errorf("expected %s, got %s, for %s + %s", want, got, d, d2)
+			}
+			if got, want := z.Sub(&x, &y).Decimal(), d.Sub(d2); !got.Equal(want) || got.exp != want.exp {
+				t.Errorf("expected %s, got %s, for %s - %s", want, got, d, d2)
+			}
+			if got, want := z.Mul(&x, &y).Decimal(), d.Mul(d2); !got.Equal(want) || got.exp != want.exp {
+				t.Errorf("expected %s, got %s, for %s * %s", want, got, d, d2)
+			}
+			if d2.IsZero() {
+				continue
+			}
+			for _, prec := range []int32{-2, 0, 3, 20} {
+				if got, want := z.Quo(&x, &y, prec).Decimal(), d.DivRound(d2, prec); !got.Equal(want) || got.exp != want.exp {
+					t.Errorf("expected %s, got %s, for %s / %s with precision %d", want, got, d, d2, prec)
+				}
+			}
+			if x.Cmp(&y) != d.Cmp(d2) {
+				t.Errorf("expected %d, got %d, for %s cmp %s", d.Cmp(d2), x.Cmp(&y), d, d2)
+			}
+		}
+	}
+}
+
+func TestBig_Round(t *testing.T) {
+	for _, s := range bigTestInputs {
+		d := RequireFromString(s)
+		var x, z Big
+		x.Set(d)
+		for _, places := range []int32{-3, -1, 0, 1, 2, 5, 40} {
+			for _, mode := range []RoundingMode{RoundHalfUp, RoundHalfEven, RoundFloor, RoundUp, Round05Up} {
+				got, want := z.Round(&x, places, mode).Decimal(), d.RoundMode(places, mode)
+				if !got.Equal(want) || got.exp != want.exp {
+					t.Errorf("expected %s, got %s, for %s rounded to %d places with %s", want, got, d, places, mode)
+				}
+			}
+		}
+	}
+}
+
+func TestBig_Aliasing(t *testing.T) {
+	var z, y Big
+	z.Set(RequireFromString("1.5"))
+	y.Set(RequireFromString("0.25"))
+
+	z.Add(&z, &y) // 1.75
+	z.Mul(&z, &z) // 3.0625
+	z.Sub(&y, &z) // -2.8125
+	z.Quo(&z, &z, 2)
+	if z.String() != "1" {
+		t.Errorf("expected 1, got %s", z.String())
+	}
+	z.Set(RequireFromString("-2.8125"))
+	z.Round(&z, 2, RoundHalfEven)
+	if z.String() != "-2.81" || z.Sign() != -1 {
+		t.Errorf("expected -2.81, got %s", z.String())
+	}
+
+	// the returned Decimal doesn't share storage with the Big
+	d := z.Decimal()
+	z.Add(&z, &y)
+	if d.String() != "-2.81" {
+		t.Errorf("expected -2.81, got %s", d)
+	}
+}
+
+func TestBig_AddAllocs(t *testing.T) {
+	amounts := []Decimal{
+		RequireFromString("19.99"), RequireFromString("-5.5"), RequireFromString("1234.567"),
+	}
+	var sum, amount Big
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, d := range amounts {
+			sum.Add(&sum, amount.Set(d))
+		}
+	})
+	if allocs > 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
+
+func TestBig_Panics(t *testing.T) {
+	var z, x, y Big
+	x.Set(New(1, 0))
+	if !didPanic(func() { z.Quo(&x, &y, 2) }) {
+		t.Errorf("expected panic for division by zero")
+	}
+	if !didPanic(func() { z.Set(NaN()) }) {
+		t.Errorf("expected panic for NaN")
+	}
+}
@@ -0,0 +1,152 @@
+package decimal
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// Coefficients that fit into an int64 are stored inline in Decimal.compact,
+// and only larger ones in a big.Int. The helpers below implement the int64
+// fast paths of the arithmetic; each of them reports false when the result
+// overflows, in which case the caller falls back to big.Int arithmetic.
+
+// maxPow10Int64 is the largest n for which 10^n fits into an int64.
+const maxPow10Int64 = 18
+
+// coef returns the coefficient of d. The result may be shared with d and must
+// not be modified.
+func (d Decimal) coef() *big.Int {
+	if d.value != nil {
+		return d.value
+	}
+	return big.NewInt(d.compact)
+}
+
+// newDecimal returns value * 10 ^ exp, storing value inline when it fits into
+// an int64. The returned Decimal takes ownership of value.
+func newDecimal(value *big.Int, exp int32) Decimal {
+	if value.IsInt64() {
+		return Decimal{compact: value.Int64(), exp: exp}
+	}
+	return Decimal{value: value, exp: exp}
+}
+
+// add64 returns a + b.
+func add64(a, b int64) (int64, bool) {
+	c := a + b
+	return c, (c > a) == (b > 0)
+}
+
+// sub64 returns a - b.
+func sub64(a, b int64) (int64, bool) {
+	c := a - b
+	return c, (c < a) == (b > 0)
+}
+
+// mul64 returns a * b.
+func mul64(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if (c < 0) != ((a < 0) != (b < 0)) || c/b != a {
+		return 0, false
+	}
+	return c, true
+}
+
+// mulPow10 returns a * 10^n for n >= 0.
+func mulPow10(a int64, n int64) (int64, bool) {
+	if a == 0 {
+		return 0, true
+	}
+	if n > maxPow10Int64 {
+		return 0, false
+	}
+	return mul64(a, int64(pow10Uint64[n]))
+}
+
+// rescalePair64 brings the compact coefficients of d and d2 to the smaller of
+// both exponents.
+func rescalePair64(d, d2 Decimal) (a, b int64, exp int32, ok bool) {
+	a, b, exp, ok = d.compact, d2.compact, d.exp, true
+	if d.exp > d2.exp {
+		a, ok = mulPow10(a, int64(d.exp)-int64(d2.exp))
+		exp = d2.exp
+	} else if d.exp < d2.exp {
+		b, ok = mulPow10(b, int64(d2.exp)-int64(d.exp))
+	}
+	return a, b, exp, ok
+}
+
+// numDigits64 returns the number of digits of abs(a).
+func numDigits64(a int64) int {
+	u := abs64(a)
+	n := 1
+	for n <= maxPow10Int64 && u >= pow10Uint64[n] {
+		n++
+	}
+	return n
+}
+
+// abs64 returns abs(a), which fits into an uint64 even for math.MinInt64.
+func abs64(a int64) uint64 {
+	if a < 0 {
+		return uint64(-(a + 1)) + 1
+	}
+	return uint64(a)
+}
+
+// coefString returns the coefficient of d in base 10.
+func (d Decimal) coefString() string {
+	if d.value == nil {
+		return strconv.FormatInt(d.compact, 10)
+	}
+	return d.value.String()
+}
+
+// absString returns the digits of the absolute value of the coefficient of d.
+func (d Decimal) absString() string {
+	if d.value == nil {
+		return strconv.FormatUint(abs64(d.compact), 10)
+	}
+	return new(big.Int).Abs(d.value).String()
+}
+
+// roundQuo64 is RoundingMode.roundQuo for int64 operands, where b is a
+// positive power of ten of at most 10^18.
+func (m RoundingMode) roundQuo64(q, r, b int64, neg bool) (int64, bool) {
+	if r == 0 {
+		return q, false
+	}
+
+	if r < 0 {
+		r = -r
+	}
+	half := 0
+	switch {
+	case 2*r < b:
+		half = -1
+	case 2*r > b:
+		half = 1
+	}
+	last := q % 10
+	if last < 0 {
+		last = -last
+	}
+
+	if m.roundsAway(half, last, neg) {
+		if neg {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q, true
+}
+
+// quoRem64 divides a by 10^n, truncating towards zero, for 0 <= n <= 18.
+func quoRem64(a int64, n int64) (q, r, b int64) {
+	b = int64(pow10Uint64[n])
+	return a / b, a % b, b
+}
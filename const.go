@@ -1,23 +1,79 @@
 package decimal
 
 import (
+	"math/big"
 	"strings"
 )
 
 const (
-	strLn10 = "2.302585092994045684017991454684364207601101488628772976033327900967572609677352480235997205089598298341967784042286248633409525465082806756666287369098781689482907208325554680843799894826233198528393505308965377732628846163366222287698219886746543667474404243274365155048934314939391479619404400222105101714174800368808401264708068556774321622835522011480466371565912137345074785694768346361679210180644507064800027750268491674655058685693567342067058113642922455440575892572420824131469568901675894025677631135691929203337658714166023010570308963457207544037084746994016826928280848118428931484852494864487192780967627127577539702766860595249671667418348570442250719796500471495105049221477656763693866297697952211071826454973477266242570942932258279850258550978526538320760672631716430950599508780752371033310119785754733154142180842754386359177811705430982748238504564801909561029929182431823752535770975053956518769751037497088869218020518933950723853920514463419726528728696511086257149219884997874887377134568620916705849807828059751193854445009978131146915934666241071846692310107598438319191292230792503747298650929009880391941702654416816335727555703151596113564846546190897042819763365836983716328982174407366009162177850541779276367731145041782137660111010731042397832521894898817597921798666394319523936855916447118246753245630912528778330963604262982153040874560927760726641354787576616262926568298704957954913954918049209069438580790032763017941503117866862092408537949861264933479354871737451675809537088281067452440105892444976479686075120275724181874989395971643105518848195288330746699317814634930000321200327765654130472621883970596794457943468343218395304414844803701305753674262153675579814770458031413637793236291560128185336498466942261465206459942072917119370602444929358037007718981097362533224548366988505528285966192805098447175198503666680874970496982273220244823343097169111136813588418696549323714996941979687803008850408979618598756579894836445212043698216415292987811742973332588607915912510967187510929248475023930572665446276200923068791518135803477701295593646298412366497023355174586195564772461857717369368404676577047874319780573853271810933883496338813069945569399346101090745616033312247949360455361849123333063704751724871276379140924398331810164737823379692265637682071706935846394531616949411701841938119405416449466111274712819705817783293841742231409930022911502362192186723337268385688273533371925103412930705632544426611429765388301822384091026198582888433587455960453004548370789052578473166283701953392231047527564998119228742789713715713228319641003422124210082180679525276689858180956119208391760721080919923461516952599099473782780648128058792731993893453415320185969711021407542282796298237068941764740642225757212455392526179373652434440560595336591539160312524480149313234572453879524389036839236450507881731359711238145323701508413491122324390927681724749607955799151363982881058285740538000653371655553014196332241918087621018204919492651483892"
+	strLn10  = "2.302585092994045684017991454684364207601101488628772976033327900967572609677352480235997205089598298341967784042286248633409525465082806756666287369098781689482907208325554680843799894826233198528393505308965377732628846163366222287698219886746543667474404243274365155048934314939391479619404400222105101714174800368808401264708068556774321622835522011480466371565912137345074785694768346361679210180644507064800027750268491674655058685693567342067058113642922455440575892572420824131469568901675894025677631135691929203337658714166023010570308963457207544037084746994016826928280848118428931484852494864487192780967627127577539702766860595249671667418348570442250719796500471495105049221477656763693866297697952211071826454973477266242570942932258279850258550978526538320760672631716430950599508780752371033310119785754733154142180842754386359177811705430982748238504564801909561029929182431823752535770975053956518769751037497088869218020518933950723853920514463419726528728696511086257149219884997874887377134568620916705849807828059751193854445009978131146915934666241071846692310107598438319191292230792503747298650929009880391941702654416816335727555703151596113564846546190897042819763365836983716328982174407366009162177850541779276367731145041782137660111010731042397832521894898817597921798666394319523936855916447118246753245630912528778330963604262982153040874560927760726641354787576616262926568298704957954913954918049209069438580790032763017941503117866862092408537949861264933479354871737451675809537088281067452440105892444976479686075120275724181874989395971643105518848195288330746699317814634930000321200327765654130472621883970596794457943468343218395304414844803701305753674262153675579814770458031413637793236291560128185336498466942261465206459942072917119370602444929358037007718981097362533224548366988505528285966192805098447175198503666680874970496982273220244823343097169111136813588418696549323714996941979687803008850408979618598756579894836445212043698216415292987811742973332588607915912510967187510929248475023930572665446276200923068791518135803477701295593646298412366497023355174586195564772461857717369368404676577047874319780573853271810933883496338813069945569399346101090745616033312247949360455361849123333063704751724871276379140924398331810164737823379692265637682071706935846394531616949411701841938119405416449466111274712819705817783293841742231409930022911502362192186723337268385688273533371925103412930705632544426611429765388301822384091026198582888433587455960453004548370789052578473166283701953392231047527564998119228742789713715713228319641003422124210082180679525276689858180956119208391760721080919923461516952599099473782780648128058792731993893453415320185969711021407542282796298237068941764740642225757212455392526179373652434440560595336591539160312524480149313234572453879524389036839236450507881731359711238145323701508413491122324390927681724749607955799151363982881058285740538000653371655553014196332241918087621018204919492651483892"
+	strPi    = "3.141592653589793238462643383279502884197169399375105820974944592307816406286208998628034825342117067982148086513282306647093844609550582231725359408128481117450284102701938521105559644622948954930381964428810975665933446128475648233786783165271201909145648566923460348610454326648213393607260249141273724587006606315588174881520920962829254091715364367892590360011330530548820466521384146951941511609433057270365759591953092186117381932611793105118548074462379962749567351885752724891227938183011949129833673362440656643086021394946395224737190702179860943702770539217176293176752384674818467669405132000568127145263560827785771342757789609173637178721468440901224953430146549585371050792279689258923542019956112129021960864034418159813629774771309960518707211349999998372978049951059731732816096318595024459455346908302642522308253344685035261931188171010003137838752886587533208381420617177669147303598253490428755468731159562863882353787593751957781857780532171226806613001927876611195909216420198938095257201065485863278865936153381827968230301952035301852968995773622599413891249721775283479131515574857242454150695950829533116861727855889075098381754637464939319255060400927701671139009848824012858361603563707660104710181942955596198946767837449448255379774726847104047534646208046684259069491293313677028989152104752162056966024058038150193511253382430035587640247496473263914199272604269922796782354781636009341721641219924586315030286182974555706749838505494588586926995690927210797509302955321165344987202755960236480665499119881834797753566369807426542527862551818417574672890977772793800081647060016145249192173217214772350141441973568548161361157352552133475741849468438523323907394143334547762416862518983569485562099219222184272550254256887671790494601653466804988627232791786085784383827967976681454100953883786360950680064225125205117392984896084128488626945604241965285022210661186306744278622039194945047123713786960956364371917287467764657573962413890865832645995813390478027590099465764078951269468398352595709825822620522489407726719478268482601476990902640136394437455305068203496252451749399651431429809190659250937221696461515709858387410597885959772975498930161753928468138268683868942774155991855925245953959431049972524680845987273644695848653836736222626099124608051243884390451244136549762780797715691435997700129616089441694868555848406353422072225828488648158456028506016842739452267467678895252138522549954666727823986456596116354886230577456498035593634568174324112515076069479451096596094025228879710893145669136867228748940560101503308617928680920874760917824938589009714909675985261365549781893129784821682998948722658804857564014270477555132379641451523746234364542858444795265867821051141354735739523113427166102135969536231442952484937187110145765403590279934403742007310578539062198387447808478489683321445713868751943506430218453191048481005370614680674919278191197939952061419663428754440643745123718192179998391015919561814675142691239748940907186494231961"
+	strE     = "2.718281828459045235360287471352662497757247093699959574966967627724076630353547594571382178525166427427466391932003059921817413596629043572900334295260595630738132328627943490763233829880753195251019011573834187930702154089149934884167509244761460668082264800168477411853742345442437107539077744992069551702761838606261331384583000752044933826560297606737113200709328709127443747047230696977209310141692836819025515108657463772111252389784425056953696770785449969967946864454905987931636889230098793127736178215424999229576351482208269895193668033182528869398496465105820939239829488793320362509443117301238197068416140397019837679320683282376464804295311802328782509819455815301756717361332069811250996181881593041690351598888519345807273866738589422879228499892086805825749279610484198444363463244968487560233624827041978623209002160990235304369941849146314093431738143640546253152096183690888707016768396424378140592714563549061303107208510383750510115747704171898610687396965521267154688957035035402123407849819334321068170121005627880235193033224745015853904730419957777093503660416997329725088687696640355570716226844716256079882651787134195124665201030592123667719432527867539855894489697096409754591856956380236370162112047742722836489613422516445078182442352948636372141740238893441247963574370263755294448337998016125492278509257782562092622648326277933386566481627725164019105900491644998289315056604725802778631864155195653244258698294695930801915298721172556347546396447910145904090586298496791287406870504895858671747985466775757320568128845920541334053922000113786300945560688166740016984205580403363795376452030402432256613527836951177883863874439662532249850654995886234281899707733276171783928034946501434558897071942586398772754710962953741521115136835062752602326484728703920764310059584116612054529703023647254929666938115137322753645098889031360205724817658511806303644281231496550704751025446501172721155519486685080036853228183152196003735625279449515828418829478761085263981395599006737648292244375287184624578036192981971399147564488262603903381441823262515097482798777996437308997038886778227138360577297882412561190717663946507063304527954661855096666185664709711344474016070462621568071748187784437143698821855967095910259686200235371858874856965220005031173439207321139080329363447972735595527734907178379342163701205005451326383544000186323991490705479778056697853358048966906295119432473099587655236812859041383241160722602998330535370876138939639177957454016137223618789365260538155841587186925538606164779834025435128439612946035291332594279490433729908573158029095863138268329147711639633709240031689458636060645845925126994655724839186564209752685082307544254599376917041977780085362730941710163434907696423722294352366125572508814779223151974778060569672538017180776360346245927877846585065605078084421152969752189087401966090665180351650179250461950136658543663271254963990854914420001457476081930221206602433009641270489439039717719518069908699860663658323227870"
+	strLn2   = "0.693147180559945309417232121458176568075500134360255254120680009493393621969694715605863326996418687542001481020570685733685520235758130557032670751635075961930727570828371435190307038623891673471123350115364497955239120475172681574932065155524734139525882950453007095326366642654104239157814952043740430385500801944170641671518644712839968171784546957026271631064546150257207402481637773389638550695260668341137273873722928956493547025762652098859693201965058554764703306793654432547632744951250406069438147104689946506220167720424524529612687946546193165174681392672504103802546259656869144192871608293803172714367782654877566485085674077648451464439940461422603193096735402574446070308096085047486638523138181676751438667476647890881437141985494231519973548803751658612753529166100071053558249879414729509293113897155998205654392871700072180857610252368892132449713893203784393530887748259701715591070882368362758984258918535302436342143670611892367891923723146723217205340164925687274778234453534764811494186423867767744060695626573796008670762571991847340226514628379048830620330611446300737194890027436439650025809365194430411911506080948793067865158870900605203468429736193841289652556539686022194122924207574321757489097706752687115817051137009158942665478595964890653058460258668382940022833005382074005677053046787001841624044188332327983863490015631218895606505531512721993983320307514084260914790012651682434438935724727882054862715527418772430024897945401961872339808608316648114909306675193393128904316413706813977764981769748689038877899912965036192707108892641052309247839173735012298424204995689359922066022046549415106139187885744245577510206837030866619480896412186807790208181588580001688115973056186676199187395200766719214592236720602539595436541655311295175989940056000366513567569051245926825743946483168332624901803824240824231452306140963805700702551387702681785163069025513703234053802145019015374029509942262995779647427138157363801729873940704242179972266962979939312706935747240493386530879758721699645129446491883771156701678598804981838896784134938314014073166472765327635919233511233389338709513209059272185471328975470797891384445466676192702885533423429899321803769154973340267546758873236778342916191810430116091695265547859732891763545556742863877463987101912431754255888301206779210280341206879759143081283307230300883494705792496591005860012341561757413272465943068435465211135021544341539955381856522750221424566440006276183303206472725721975152908278568421320795988638967277119552218819046603957009774706512619505278932296088931405625433442552392062030343941777357945592125901992559114844024239012554259003129537051922061506434583787873002035414421785758013236451660709914383145004985896688577222148652882169418127048860758972203216663128378329156763074987298574638928269373509840778049395004933998762647550703162216139034845299424917248373406136622638349368111684167056925214751383930638455371862687797328895558871634429756244755392366369488877823890174981027"
+	strSqrt2 = "1.414213562373095048801688724209698078569671875376948073176679737990732478462107038850387534327641572735013846230912297024924836055850737212644121497099935831413222665927505592755799950501152782060571470109559971605970274534596862014728517418640889198609552329230484308714321450839762603627995251407989687253396546331808829640620615258352395054745750287759961729835575220337531857011354374603408498847160386899970699004815030544027790316454247823068492936918621580578463111596668713013015618568987237235288509264861249497715421833420428568606014682472077143585487415565706967765372022648544701585880162075847492265722600208558446652145839889394437092659180031138824646815708263010059485870400318648034219489727829064104507263688131373985525611732204024509122770022694112757362728049573810896750401836986836845072579936472906076299694138047565482372899718032680247442062926912485905218100445984215059112024944134172853147810580360337107730918286931471017111168391658172688941975871658215212822951848847208969463386289156288276595263514054226765323969461751129160240871551013515045538128756005263146801712740265396947024030051749531886292563138518816347800156936917688185237868405228783762938921430065586956868596459515550164472450983689603688732311438941557665104088391429233811320605243362948531704991577175622854974143899918802176243096520656421182731672625753959471725593463723863226148274262220867115583959992652117625269891754098815934864008345708518147223181420407042650905653233339843645786579679651926729239987536661721598257886026336361782749599421940377775368142621773879919455139723127406689832998989538672882285637869774966251996658352577619893932284534473569479496295216889148549253890475582883452609652409654288939453864662574492755638196441031697983306185201937938494005715633372054806854057586799967012137223947582142630658513221740883238294728761739364746783743196000159218880734785761725221186749042497736692920731109636972160893370866115673458533483329525467585164471075784860246360083444911481858765555428645512331421992631133251797060843655970435285641008791850076036100915946567067688360557174007675690509613671940132493560524018599910506210816359772643138060546701029356997104242510578174953105725593498445112692278034491350663756874776028316282960553242242695753452902883876844642917328277088831808702533985233812274999081237189254072647536785030482159180188616710897286922920119759988070381854333253646021108229927929307287178079988809917674177410898306080032631181642798823117154363869661702999934161614878686018045505553986913115186010386375325004558186044804075024119518430567453368361367459737442398855328517930896037389891517319587413442881784212502191695187559344438739618931454999990610758704909026088351763622474975785885836803745793115733980209998662218694992259591327642361941059210032802614987456659968887406795616739185957288864247346358588686449682238600698335264279905628316561391394255764906206518602164726303336297507569787060660685649816009271870929215313236828"
 )
 
 var (
-	ln10 = newConstApproximation(strLn10)
+	ln10  = newConstApproximation(strLn10, computeLn10)
+	pi    = newConstApproximation(strPi, computePi)
+	euler = newConstApproximation(strE, computeE)
+	ln2   = newConstApproximation(strLn2, computeLn2)
+	sqrt2 = newConstApproximation(strSqrt2, computeSqrt2)
 )
 
+// Pi returns the number Pi rounded half up to precision digits after the
+// decimal point. Its first 3000 places are stored, more are computed by
+// Machin's formula.
+//
+// Example:
+//
+//	Pi(10).String() // output: "3.1415926536"
+func Pi(precision int32) Decimal {
+	return pi.rounded(precision)
+}
+
+// E returns Euler's number e rounded half up to precision digits after the
+// decimal point. Its first 3000 places are stored, more are computed by the
+// series of 1/k!.
+//
+// Example:
+//
+//	E(10).String() // output: "2.7182818285"
+func E(precision int32) Decimal {
+	return euler.rounded(precision)
+}
+
+// Ln2 returns the natural logarithm of 2 rounded half up to precision digits
+// after the decimal point. Its first 3000 places are stored, more are computed
+// by a Machin-like formula of inverse hyperbolic cotangents.
+//
+// Example:
+//
+//	Ln2(10).String() // output: "0.6931471806"
+func Ln2(precision int32) Decimal {
+	return ln2.rounded(precision)
+}
+
+// Sqrt2 returns the square root of 2 rounded half up to precision digits after
+// the decimal point. Its first 3000 places are stored, more are computed by an
+// integer square root.
+//
+// Example:
+//
+//	Sqrt2(10).String() // output: "1.4142135624"
+func Sqrt2(precision int32) Decimal {
+	return sqrt2.rounded(precision)
+}
+
 type constApproximation struct {
 	exact          Decimal
 	approximations []Decimal
+	// compute returns the constant with an error of less than a unit in the
+	// given places, when they're more than those of exact. It may be nil.
+	compute func(places int32) Decimal
 }
 
-func newConstApproximation(value string) constApproximation {
+func newConstApproximation(value string, compute func(places int32) Decimal) constApproximation {
 	parts := strings.Split(value, ".")
 	coeff, fractional := parts[0], parts[1]
 
@@ -33,12 +89,18 @@ func newConstApproximation(value string) constApproximation {
 	return constApproximation{
 		RequireFromString(value),
 		approximations,
+		compute,
 	}
 }
 
 // Returns the smallest approximation available that's at least as precise
-// as the passed precision (places after decimal point), i.e. Floor[ log2(precision) ] + 1
+// as the passed precision (places after decimal point), i.e. Floor[ log2(precision) ] + 1.
+// Approximations beyond the stored digits are computed if possible.
 func (c constApproximation) withPrecision(precision int32) Decimal {
+	if precision > -c.exact.exp && c.compute != nil {
+		return c.compute(precision)
+	}
+
 	i := 0
 
 	if precision >= 1 {
@@ -61,3 +123,98 @@ func (c constApproximation) withPrecision(precision int32) Decimal {
 
 	return c.approximations[i]
 }
+
+// rounded returns the constant rounded half up to the given places. The stored
+// digits are truncated, so that one more place than requested decides the
+// rounding of the irrational constants.
+func (c constApproximation) rounded(places int32) Decimal {
+	if places < -c.exact.exp {
+		return c.withPrecision(places + 1).Round(places)
+	}
+	r, _ := roundApprox(places, func(p int32) (Decimal, error) {
+		return c.withPrecision(p), nil
+	})
+	return r
+}
+
+// constGuard is the number of extra digits of the fixed-point computations of
+// constants, which covers the truncation error of each term of their series.
+const constGuard = 10
+
+// constUnity returns 10^(places+constGuard), the fixed-point representation of 1.
+func constUnity(places int32) *big.Int {
+	return new(big.Int).Exp(tenInt, big.NewInt(int64(places)+constGuard), nil)
+}
+
+// constDecimal returns the fixed-point value v as a Decimal.
+func constDecimal(v *big.Int, places int32) Decimal {
+	return newDecimal(v, -places-constGuard)
+}
+
+// arccot returns the fixed-point inverse cotangent of x, or the inverse
+// hyperbolic cotangent if hyperbolic is set, by the series
+// 1/x ∓ 1/(3x^3) + 1/(5x^5) ∓ ...
+func arccot(x int64, unity *big.Int, hyperbolic bool) *big.Int {
+	bx := big.NewInt(x)
+	x2 := big.NewInt(x * x)
+	sum := new(big.Int).Quo(unity, bx)
+	pow := new(big.Int).Set(sum)
+	var term big.Int
+	for n := int64(3); pow.Sign() != 0; n += 2 {
+		pow.Quo(pow, x2)
+		term.Quo(pow, big.NewInt(n))
+		if hyperbolic || n%4 == 1 {
+			sum.Add(sum, &term)
+		} else {
+			sum.Sub(sum, &term)
+		}
+	}
+	return sum
+}
+
+// computePi returns Pi = 16 acot(5) - 4 acot(239) by Machin's formula.
+func computePi(places int32) Decimal {
+	unity := constUnity(places)
+	v := new(big.Int).Lsh(arccot(5, unity, false), 4)
+	v.Sub(v, new(big.Int).Lsh(arccot(239, unity, false), 2))
+	return constDecimal(v, places)
+}
+
+// computeE returns e as the sum of 1/k!.
+func computeE(places int32) Decimal {
+	unity := constUnity(places)
+	sum := new(big.Int).Set(unity)
+	term := new(big.Int).Set(unity)
+	for k := int64(1); term.Sign() != 0; k++ {
+		term.Quo(term, big.NewInt(k))
+		sum.Add(sum, term)
+	}
+	return constDecimal(sum, places)
+}
+
+// computeLn2 returns ln(2) = 18 acoth(26) - 2 acoth(4801) + 8 acoth(8749).
+func computeLn2(places int32) Decimal {
+	return constDecimal(fixedLn2(constUnity(places)), places)
+}
+
+func fixedLn2(unity *big.Int) *big.Int {
+	v := new(big.Int).Mul(arccot(26, unity, true), big.NewInt(18))
+	v.Sub(v, new(big.Int).Lsh(arccot(4801, unity, true), 1))
+	return v.Add(v, new(big.Int).Lsh(arccot(8749, unity, true), 3))
+}
+
+// computeLn10 returns ln(10) = 3 ln(2) + ln(5/4), with ln(5/4) = 2 acoth(9).
+func computeLn10(places int32) Decimal {
+	unity := constUnity(places)
+	v := new(big.Int).Mul(fixedLn2(unity), big.NewInt(3))
+	v.Add(v, new(big.Int).Lsh(arccot(9, unity, true), 1))
+	return constDecimal(v, places)
+}
+
+// computeSqrt2 returns the integer square root of 2 * unity^2.
+func computeSqrt2(places int32) Decimal {
+	unity := constUnity(places)
+	v := new(big.Int).Mul(unity, unity)
+	v.Lsh(v, 1)
+	return constDecimal(new(big.Int).Sqrt(v), places)
+}
@@ -1,6 +1,9 @@
 package decimal
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestConstApproximation(t *testing.T) {
 	for _, testCase := range []struct {
@@ -22,7 +25,7 @@ func TestConstApproximation(t *testing.T) {
 		{"3.14159265359", 4, "3.1415926"},
 		{"3.14159265359", 13, "3.14159265359"},
 	} {
-		ca := newConstApproximation(testCase.Const)
+		ca := newConstApproximation(testCase.Const, nil)
 		expected, _ := NewFromString(testCase.ExpectedApproximation)
 
 		approximation := ca.withPrecision(testCase.Precision)
@@ -32,3 +35,55 @@ func TestConstApproximation(t *testing.T) {
 		}
 	}
 }
+
+func TestConstants(t *testing.T) {
+	consts := map[string]func(int32) Decimal{
+		"Pi":    Pi,
+		"E":     E,
+		"Ln2":   Ln2,
+		"Sqrt2": Sqrt2,
+	}
+	for _, test := range []struct {
+		name      string
+		precision int32
+		want      string
+	}{
+		{"Pi", 0, "3"},
+		{"Pi", 1, "3.1"},
+		{"Pi", 20, "3.14159265358979323846"},
+		{"Pi", -1, "0"},
+		{"E", 0, "3"},
+		{"E", 1, "2.7"},
+		{"E", 20, "2.71828182845904523536"},
+		{"Ln2", 0, "1"},
+		{"Ln2", 1, "0.7"},
+		{"Ln2", 20, "0.69314718055994530942"},
+		{"Sqrt2", 0, "1"},
+		{"Sqrt2", 1, "1.4"},
+		{"Sqrt2", 20, "1.4142135623730950488"},
+	} {
+		got := consts[test.name](test.precision)
+		if got.String() != test.want {
+			t.Errorf("expected %s(%d) = %s, got %s", test.name, test.precision, test.want, got)
+		}
+	}
+
+	// the places beyond the stored digits are computed
+	for _, test := range []struct {
+		name, suffix string
+	}{
+		{"Pi", "90718649423196156795"},
+		{"E", "66365832322787093765"},
+		{"Ln2", "82389017498102735655"},
+		{"Sqrt2", "9292153132368281357"},
+	} {
+		got := consts[test.name](3005).String()
+		if !strings.HasSuffix(got, test.suffix) {
+			t.Errorf("expected %s(3005) to end with %s, got %s", test.name, test.suffix, got[len(got)-20:])
+		}
+	}
+
+	if got := ln10.withPrecision(3050).Truncate(3000); !got.Equal(ln10.exact) {
+		t.Errorf("expected computed ln(10) to match its stored digits")
+	}
+}
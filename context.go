@@ -0,0 +1,803 @@
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// RoundingMode specifies how a value is rounded when it cannot be represented
+// exactly with the requested precision.
+//
+// The zero value is RoundHalfUp, the rounding used by Round and DivRound.
+type RoundingMode uint8
+
+const (
+	// RoundHalfUp rounds to the nearest neighbour, ties are rounded away from zero.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfDown rounds to the nearest neighbour, ties are rounded towards zero.
+	RoundHalfDown
+	// RoundHalfEven rounds to the nearest neighbour, ties are rounded to the even neighbour (banker's rounding).
+	RoundHalfEven
+	// RoundHalfOdd rounds to the nearest neighbour, ties are rounded to the odd neighbour.
+	RoundHalfOdd
+	// RoundCeiling rounds towards +infinity.
+	RoundCeiling
+	// RoundFloor rounds towards -infinity.
+	RoundFloor
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundDown rounds towards zero, i.e. truncates.
+	RoundDown
+	// Round05Up rounds away from zero if the last kept digit is 0 or 5, otherwise towards zero.
+	Round05Up
+)
+
+var roundingModeNames = [...]string{
+	RoundHalfUp:   "HalfUp",
+	RoundHalfDown: "HalfDown",
+	RoundHalfEven: "HalfEven",
+	RoundHalfOdd:  "HalfOdd",
+	RoundCeiling:  "Ceiling",
+	RoundFloor:    "Floor",
+	RoundUp:       "Up",
+	RoundDown:     "Down",
+	Round05Up:     "05Up",
+}
+
+// String returns the name of the rounding mode, e.g. "HalfEven" for RoundHalfEven.
+func (m RoundingMode) String() string {
+	if int(m) < len(roundingModeNames) {
+		return roundingModeNames[m]
+	}
+	return fmt.Sprintf("RoundingMode(%d)", m)
+}
+
+// ParseRoundingMode returns the rounding mode with the given name, so the mode
+// can be selected by configuration. Names are matched case-insensitively and
+// ignoring underscores, dashes and spaces, with an optional "Round" prefix,
+// thus "HalfEven", "half_even", "ROUND_HALF_EVEN" and "round-half-even" all
+// select RoundHalfEven.
+func ParseRoundingMode(name string) (RoundingMode, error) {
+	key := strings.NewReplacer("_", "", "-", "", " ", "").Replace(strings.ToLower(name))
+	key = strings.TrimPrefix(key, "round")
+	for m, n := range roundingModeNames {
+		if key == strings.ToLower(n) {
+			return RoundingMode(m), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown rounding mode %q", name)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (m RoundingMode) MarshalText() ([]byte, error) {
+	if int(m) >= len(roundingModeNames) {
+		return nil, fmt.Errorf("invalid rounding mode %d", m)
+	}
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface,
+// accepting the names recognized by ParseRoundingMode.
+func (m *RoundingMode) UnmarshalText(text []byte) error {
+	mode, err := ParseRoundingMode(string(text))
+	if err != nil {
+		return err
+	}
+	*m = mode
+	return nil
+}
+
+// roundQuo rounds, in place, the quotient q of a division truncated towards zero.
+// r is the remainder and b the divisor of that division, so that r/b is the
+// discarded fraction of the last digit of q, and neg reports whether the exact
+// quotient is negative. It reports whether the quotient was inexact.
+// r is used as scratch space and must not be used afterwards.
+func (m RoundingMode) roundQuo(q, r, b *big.Int, neg bool) bool {
+	if r.Sign() == 0 {
+		return false
+	}
+
+	var half int
+	var last int64
+	switch m {
+	case RoundHalfUp, RoundHalfDown, RoundHalfEven, RoundHalfOdd:
+		// compare the discarded fraction with one half, i.e. 2 * abs(r) with abs(b)
+		r.Lsh(r.Abs(r), 1)
+		half = r.CmpAbs(b)
+		last = int64(q.Bit(0))
+	case Round05Up:
+		var digit big.Int
+		last = digit.Rem(q, tenInt).Int64()
+		if last < 0 {
+			last = -last
+		}
+	}
+
+	if m.roundsAway(half, last, neg) {
+		if neg {
+			q.Sub(q, oneInt)
+		} else {
+			q.Add(q, oneInt)
+		}
+	}
+	return true
+}
+
+// roundsAway reports whether an inexact quotient truncated towards zero must
+// be rounded away from zero. half compares the discarded fraction with one
+// half, last is the last digit of the absolute value of the truncated quotient
+// (only its parity matters to the half even and half odd modes), and neg
+// reports whether the quotient is negative.
+func (m RoundingMode) roundsAway(half int, last int64, neg bool) bool {
+	switch m {
+	case RoundHalfUp:
+		return half >= 0
+	case RoundHalfDown:
+		return half > 0
+	case RoundHalfEven:
+		return half > 0 || half == 0 && last%2 == 1
+	case RoundHalfOdd:
+		return half > 0 || half == 0 && last%2 == 0
+	case RoundCeiling:
+		return !neg
+	case RoundFloor:
+		return neg
+	case RoundUp:
+		return true
+	case RoundDown:
+		return false
+	case Round05Up:
+		return last == 0 || last == 5
+	}
+	panic(fmt.Sprintf("invalid rounding mode %d", m))
+}
+
+// roundMode rounds d to places decimal places using the rounding mode m and
+// reports whether digits other than zero were discarded. Unlike Round, d is
+// returned unchanged when it has no more than places decimal places.
+func (d Decimal) roundMode(places int32, m RoundingMode) (Decimal, bool) {
+	if d.form != formFinite || d.exp >= -places {
+		return d, false
+	}
+
+	// NOTE(vadim): must convert exps to int64 before - to prevent overflow
+	diff := -int64(places) - int64(d.exp)
+	if d.value == nil && diff <= maxPow10Int64 {
+		q, r, b := quoRem64(d.compact, diff)
+		q, inexact := m.roundQuo64(q, r, b, d.compact < 0)
+		return New(q, -places), inexact
+	}
+
+	b := new(big.Int).Exp(tenInt, big.NewInt(diff), nil)
+	q, r := new(big.Int).QuoRem(d.coef(), b, new(big.Int))
+	inexact := m.roundQuo(q, r, b, d.Sign() < 0)
+
+	return newDecimal(q, -places), inexact
+}
+
+// adjusted returns the exponent of the most significant digit of d,
+// e.g. 2 for 123.45 and -3 for 0.00123.
+func (d Decimal) adjusted() int32 {
+	return d.exp + int32(d.NumDigits()) - 1
+}
+
+// reduce removes trailing zeros from the coefficient of d, increasing its
+// exponent by one for each removed zero, but not beyond maxExp.
+func (d Decimal) reduce(maxExp int32) Decimal {
+	if d.exp >= maxExp {
+		return d
+	}
+
+	if d.Sign() == 0 {
+		return New(0, maxExp)
+	}
+
+	if d.value == nil {
+		c, exp := d.compact, d.exp
+		for exp < maxExp && c%10 == 0 {
+			c /= 10
+			exp++
+		}
+		return New(c, exp)
+	}
+
+	var q, r big.Int
+	value := new(big.Int).Set(d.coef())
+	exp := d.exp
+	for exp < maxExp {
+		q.QuoRem(value, tenInt, &r)
+		if r.Sign() != 0 {
+			break
+		}
+		value.Set(&q)
+		exp++
+	}
+
+	return newDecimal(value, exp)
+}
+
+// Condition is a set of exceptional conditions signaled by the operations of a Context.
+type Condition uint32
+
+const (
+	// DivisionByZero is signaled when a non-zero number is divided by zero,
+	// or when the result is an exact infinity, e.g. the natural logarithm of zero.
+	DivisionByZero Condition = 1 << iota
+	// Inexact is signaled when a result was rounded and digits other than zero were discarded.
+	Inexact
+	// Overflow is signaled when the exponent of a result doesn't fit into an int32.
+	Overflow
+	// InvalidOperation is signaled when a result is undefined,
+	// e.g. 0/0, 0**0 or the logarithm of a negative number.
+	InvalidOperation
+)
+
+// DefaultTraps is the set of conditions that make an operation return an
+// error instead of NaN or an infinity.
+const DefaultTraps = DivisionByZero | Overflow | InvalidOperation
+
+var conditionNames = []struct {
+	cond Condition
+	name string
+}{
+	{DivisionByZero, "division by zero"},
+	{Inexact, "inexact"},
+	{Overflow, "overflow"},
+	{InvalidOperation, "invalid operation"},
+}
+
+// String returns the names of the conditions in c separated by commas.
+func (c Condition) String() string {
+	var names []string
+	for _, n := range conditionNames {
+		if c&n.cond != 0 {
+			names = append(names, n.name)
+		}
+	}
+	if len(names) == 0 {
+		return "no conditions"
+	}
+	return strings.Join(names, ", ")
+}
+
+// ConditionError is the error returned by the operations of a Context when they
+// signal a condition included in the context traps.
+type ConditionError struct {
+	// Condition is the set of trapped conditions that were signaled.
+	Condition Condition
+	msg       string
+}
+
+func (e *ConditionError) Error() string {
+	if e.msg == "" {
+		return e.Condition.String()
+	}
+	return e.msg
+}
+
+// Is reports whether the error matches target. It lets errors.Is match the
+// errors of trapped DivisionByZero and Overflow conditions with
+// ErrDivisionByZero and ErrExponentOverflow.
+func (e *ConditionError) Is(target error) bool {
+	switch target {
+	case ErrDivisionByZero:
+		return e.Condition&DivisionByZero != 0
+	case ErrExponentOverflow:
+		return e.Condition&Overflow != 0
+	}
+	return false
+}
+
+// guardDigits is the number of additional digits computed by approximating
+// Context operations before the result is rounded to the context precision.
+const guardDigits = 3
+
+// Context describes the precision and rounding of arithmetic operations, the
+// conditions that are reported as errors and the conditions that were signaled
+// so far. It's modeled on the General Decimal Arithmetic specification,
+// see http://speleotrove.com/decimal/decarith.html for more details.
+//
+// Contrary to the DivisionPrecision and PowPrecisionNegativeExponent package
+// variables, a Context is a plain value, so goroutines and libraries that need
+// different precisions don't interfere with each other. A Context collects the
+// signaled conditions in Flags, thus a single Context must not be shared by
+// goroutines running concurrently.
+//
+// When a signaled condition isn't trapped, the operation returns a nil error
+// and the IEEE 754 result: NaN for an undefined result, a signed infinity for
+// a division by zero or an overflow. Operations on special values follow the
+// rules of Decimal arithmetic, and a signaling NaN operand signals
+// InvalidOperation.
+//
+// Example:
+//
+//	ctx := decimal.Context{Precision: 4, Significant: true, Traps: decimal.DefaultTraps}
+//	q, err := ctx.Div(decimal.NewFromInt(2), decimal.NewFromInt(3))
+//	q.String()                      // output: "0.6667"
+//	ctx.Flags&decimal.Inexact != 0  // output: true
+type Context struct {
+	// Precision is the precision of rounded results, i.e. the number of digits
+	// after the decimal point, or the number of significant digits when
+	// Significant is set. A negative number of digits after the decimal point
+	// rounds results to a multiple of 10^(-Precision).
+	Precision int32
+
+	// Significant specifies whether Precision counts significant digits instead
+	// of digits after the decimal point. In that case a Precision lower than one
+	// keeps all digits of Add, Sub and Mul results, while the approximating
+	// operations signal InvalidOperation.
+	Significant bool
+
+	// Rounding is the rounding mode applied to results that don't fit into Precision.
+	Rounding RoundingMode
+
+	// Traps is the set of conditions that make an operation return an error.
+	Traps Condition
+
+	// Flags is the set of conditions signaled by the operations performed
+	// through the context. It's never cleared by the context itself.
+	Flags Condition
+}
+
+// signal records cond in the context flags and returns a *ConditionError
+// if any of the conditions is trapped.
+func (c *Context) signal(cond Condition, msg string) error {
+	c.Flags |= cond
+	if trapped := cond & c.Traps; trapped != 0 {
+		return &ConditionError{Condition: trapped, msg: msg}
+	}
+	return nil
+}
+
+// fail signals cond and returns r, the result of the exceptional operation,
+// unless cond is trapped.
+func (c *Context) fail(r Decimal, cond Condition, msg string) (Decimal, error) {
+	if err := c.signal(cond, msg); err != nil {
+		return Decimal{}, err
+	}
+	return r, nil
+}
+
+// nonFinite returns r, the result of an operation involving non-finite
+// operands. InvalidOperation is signaled when an operand is a signaling NaN,
+// or when r is a NaN that isn't propagated from the operands.
+func (c *Context) nonFinite(r Decimal, operands ...Decimal) (Decimal, error) {
+	invalid := r.IsNaN()
+	for _, o := range operands {
+		if o.form == formSNaN {
+			invalid = true
+			break
+		}
+		if o.form == formNaN {
+			invalid = false
+		}
+	}
+	if invalid {
+		return c.fail(NaN(), InvalidOperation, "invalid operation on "+r.String())
+	}
+	return r, nil
+}
+
+// result signals Inexact for an inexact result d.
+func (c *Context) result(d Decimal, inexact bool) (Decimal, error) {
+	if inexact {
+		if err := c.signal(Inexact, ""); err != nil {
+			return Decimal{}, err
+		}
+	}
+	return d, nil
+}
+
+// round rounds d to the context precision and reports whether the result is inexact.
+func (c *Context) round(d Decimal) (Decimal, bool) {
+	if !c.Significant {
+		return d.roundMode(c.Precision, c.Rounding)
+	}
+
+	n := int32(d.NumDigits())
+	if c.Precision <= 0 || n <= c.Precision {
+		return d, false
+	}
+
+	r, inexact := d.roundMode(-(d.exp + n - c.Precision), c.Rounding)
+	if r.NumDigits() > int(c.Precision) {
+		// rounding carried into a new digit, e.g. 999 -> 1000
+		r = r.rescale(r.exp + 1)
+	}
+	return r, inexact
+}
+
+// Round rounds d to the precision of the context using its rounding mode.
+// Values that fit into the precision are returned unchanged.
+func (c *Context) Round(d Decimal) (Decimal, error) {
+	if d.form != formFinite {
+		return c.nonFinite(d, d)
+	}
+	return c.result(c.round(d))
+}
+
+// Add returns d + d2 rounded to the precision of the context.
+// The sum of infinities of opposite signs signals InvalidOperation.
+func (c *Context) Add(d, d2 Decimal) (Decimal, error) {
+	if d.form != formFinite || d2.form != formFinite {
+		return c.nonFinite(d.Add(d2), d, d2)
+	}
+	return c.Round(d.Add(d2))
+}
+
+// Sub returns d - d2 rounded to the precision of the context.
+func (c *Context) Sub(d, d2 Decimal) (Decimal, error) {
+	if d.form != formFinite || d2.form != formFinite {
+		return c.nonFinite(d.Sub(d2), d, d2)
+	}
+	return c.Round(d.Sub(d2))
+}
+
+// Mul returns d * d2 rounded to the precision of the context.
+// Overflow is signaled instead of panicking when the exponent overflows an int32,
+// the product of an infinity and zero signals InvalidOperation.
+func (c *Context) Mul(d, d2 Decimal) (Decimal, error) {
+	if d.form != formFinite || d2.form != formFinite {
+		return c.nonFinite(d.Mul(d2), d, d2)
+	}
+	expInt64 := int64(d.exp) + int64(d2.exp)
+	if expInt64 > math.MaxInt32 || expInt64 < math.MinInt32 {
+		// the product is either too large or too small to be represented
+		r := Decimal{}
+		if expInt64 > 0 && !d.IsZero() && !d2.IsZero() {
+			r = Decimal{form: formInfinite, neg: d.Sign() != d2.Sign()}
+		}
+		return c.fail(r, Overflow, fmt.Sprintf("exponent %v overflows an int32!", expInt64))
+	}
+	return c.Round(d.Mul(d2))
+}
+
+// MulAdd returns d * d2 + d3 rounded once to the precision of the context.
+// Contrary to Mul followed by Add, the product isn't rounded before d3 is added,
+// so the result doesn't depend on the order of operations.
+//
+// Example:
+//
+//	ctx := decimal.Context{Precision: 2, Rounding: decimal.RoundHalfUp}
+//	price := decimal.RequireFromString("0.335")
+//	ctx.MulAdd(decimal.NewFromInt(3), price, decimal.RequireFromString("0.005")) // output: "1.01"
+func (c *Context) MulAdd(d, d2, d3 Decimal) (Decimal, error) {
+	return c.DotProduct([]Decimal{d, d3}, []Decimal{d2, New(1, 0)})
+}
+
+// MulSub returns d * d2 - d3 rounded once to the precision of the context, see MulAdd.
+func (c *Context) MulSub(d, d2, d3 Decimal) (Decimal, error) {
+	return c.DotProduct([]Decimal{d, d3}, []Decimal{d2, New(-1, 0)})
+}
+
+// DotProduct returns the sum of the products xs[i] * ys[i], computed exactly
+// and rounded once to the precision of the context. The dot product of empty
+// slices is 0. It returns an error if the slices have different lengths.
+//
+// A product whose exponent overflows an int32 signals Overflow, as in Mul.
+func (c *Context) DotProduct(xs, ys []Decimal) (Decimal, error) {
+	if len(xs) != len(ys) {
+		return Decimal{}, fmt.Errorf("can't compute the dot product of %d and %d decimals", len(xs), len(ys))
+	}
+
+	var sum Decimal
+	special := false
+	for i, x := range xs {
+		y := ys[i]
+		var p Decimal
+		if x.form != formFinite || y.form != formFinite {
+			special = true
+			p = x.Mul(y)
+		} else if expInt64 := int64(x.exp) + int64(y.exp); expInt64 > math.MaxInt32 || expInt64 < math.MinInt32 {
+			var err error
+			if p, err = c.Mul(x, y); err != nil {
+				return Decimal{}, err
+			}
+		} else {
+			p = x.Mul(y)
+		}
+
+		if i == 0 {
+			sum = p
+		} else {
+			sum = sum.Add(p)
+		}
+	}
+
+	if special {
+		operands := make([]Decimal, 0, len(xs)+len(ys))
+		return c.nonFinite(sum, append(append(operands, xs...), ys...)...)
+	}
+	return c.Round(sum)
+}
+
+// Div returns d / d2 rounded to the precision of the context.
+//
+// When the precision counts digits after the decimal point, the quotient has
+// exactly Precision decimal places, as the result of DivRound. Otherwise the
+// quotient is rounded to Precision significant digits, and the trailing zeros
+// of an exact quotient are removed down to the exponent d.Exponent() - d2.Exponent().
+//
+// Dividing zero by zero signals InvalidOperation, dividing any other number by
+// zero signals DivisionByZero and returns an infinity with the sign of the
+// operands, taking a negative zero divisor into account.
+func (c *Context) Div(d, d2 Decimal) (Decimal, error) {
+	if d.form != formFinite || d2.form != formFinite {
+		return c.nonFinite(quoSpecial(d, d2), d, d2)
+	}
+	if d2.Sign() == 0 {
+		if d.Sign() == 0 {
+			return c.fail(NaN(), InvalidOperation, "decimal division by 0")
+		}
+		return c.fail(Decimal{form: formInfinite, neg: d.Signbit() != d2.Signbit()}, DivisionByZero, "decimal division by 0")
+	}
+
+	places := int64(c.Precision)
+	if c.Significant {
+		if c.Precision <= 0 {
+			return c.fail(NaN(), InvalidOperation, "division requires a positive precision")
+		}
+		places = int64(c.Precision) - 1 - quoAdjusted(d, d2)
+	}
+	if places > math.MaxInt32 || places < math.MinInt32 {
+		return c.fail(NaN(), Overflow, "overflow in decimal QuoRem")
+	}
+
+	q, r, bb, _, ok := d.quoRem(d2, int32(places))
+	if !ok {
+		return c.fail(NaN(), Overflow, "overflow in decimal QuoRem")
+	}
+	inexact := c.Rounding.roundQuo(q, r, bb, d.Sign() != d2.Sign())
+	res := newDecimal(q, -int32(places))
+
+	if c.Significant {
+		if inexact {
+			res, _ = c.round(res)
+		} else {
+			res = res.reduce(d.exp - d2.exp)
+		}
+	}
+
+	return c.result(res, inexact)
+}
+
+// quoSpecial returns d / d2 when d or d2 isn't finite.
+func quoSpecial(d, d2 Decimal) Decimal {
+	if d.IsNaN() || d2.IsNaN() || (d.form == formInfinite && d2.form == formInfinite) {
+		return NaN()
+	}
+	neg := d.Signbit() != d2.Signbit()
+	if d.form == formInfinite {
+		return Decimal{form: formInfinite, neg: neg}
+	}
+	return Decimal{neg: neg}
+}
+
+// quoAdjusted returns the exponent of the most significant digit of d / d2.
+// d2 must be non-zero.
+func quoAdjusted(d, d2 Decimal) int64 {
+	n, n2 := d.NumDigits(), d2.NumDigits()
+	adj := int64(d.exp) + int64(n) - int64(d2.exp) - int64(n2)
+
+	// compare coefficients aligned on their most significant digits
+	a := new(big.Int).Abs(d.coef())
+	b := new(big.Int).Abs(d2.coef())
+	if n < n2 {
+		a.Mul(a, new(big.Int).Exp(tenInt, big.NewInt(int64(n2-n)), nil))
+	} else if n > n2 {
+		b.Mul(b, new(big.Int).Exp(tenInt, big.NewInt(int64(n-n2)), nil))
+	}
+	if a.Cmp(b) < 0 {
+		adj--
+	}
+
+	return adj
+}
+
+// Pow returns d to the power of d2 rounded to the precision of the context.
+//
+// Integer powers are computed exactly before they're rounded, negative ones
+// by dividing one by the exact positive power. Powers with a fractional
+// exponent are approximated with guard digits, so they might be off by one
+// unit in the last place.
+//
+// 0**0 and powers of negative numbers with a fractional exponent signal
+// InvalidOperation, negative powers of zero signal DivisionByZero.
+func (c *Context) Pow(d, d2 Decimal) (Decimal, error) {
+	if d.form != formFinite || d2.form != formFinite {
+		return c.nonFinite(powSpecial(d, d2), d, d2)
+	}
+	if d2.IsInteger() {
+		if d2.Sign() < 0 && !d.IsZero() {
+			p, err := c.pow(d, d2.Neg(), 0)
+			if err != nil {
+				return Decimal{}, err
+			}
+			return c.Div(New(1, 0), p)
+		}
+		p, err := c.pow(d, d2, 0)
+		if err != nil {
+			return Decimal{}, err
+		}
+		return c.Round(p)
+	}
+
+	if !c.Significant {
+		p, err := c.pow(d, d2, c.Precision+guardDigits)
+		if err != nil {
+			return Decimal{}, err
+		}
+		return c.Round(p)
+	}
+
+	if d.IsZero() || d.IsNegative() {
+		// only signals the undefined results, as 0**y is exact
+		p, err := c.pow(d, d2, 0)
+		if err != nil || !p.IsFinite() || p.IsZero() {
+			return p, err
+		}
+	}
+
+	// log10(d**d2) = d2 * log10(d)
+	adj := math.Floor(d2.InexactFloat64() * log10Abs(d))
+	return c.approximate(adj, func(places int32) (Decimal, error) {
+		return c.pow(d, d2, places)
+	})
+}
+
+// pow implements PowWithPrecision. The result has at least precision correct
+// digits after the decimal point, but isn't rounded.
+func (c *Context) pow(d, d2 Decimal, precision int32) (Decimal, error) {
+	if d.form != formFinite || d2.form != formFinite {
+		return c.nonFinite(powSpecial(d, d2), d, d2)
+	}
+
+	baseSign := d.Sign()
+	expSign := d2.Sign()
+
+	if baseSign == 0 {
+		if expSign == 0 {
+			return c.fail(NaN(), InvalidOperation, "cannot represent undefined value of 0**0")
+		}
+		if expSign == 1 {
+			return New(0, 0), nil
+		}
+		if expSign == -1 {
+			return c.fail(Inf(1), DivisionByZero, "cannot represent infinity value of 0 ** y, where y < 0")
+		}
+	}
+
+	if expSign == 0 {
+		return New(1, 0), nil
+	}
+
+	// TODO: optimize extraction of fractional part
+	one := New(1, 0)
+	expIntPart, expFracPart := d2.QuoRem(one, 0)
+
+	if baseSign == -1 && !expFracPart.IsZero() {
+		return c.fail(NaN(), InvalidOperation, "cannot represent imaginary value of x ** y, where x < 0 and y is non-integer decimal")
+	}
+
+	intPartPow, _ := d.powBigIntWithPrecision(expIntPart.coef(), precision)
+
+	// if exponent is an integer we don't need to calculate d1**frac(d2)
+	if expFracPart.Sign() == 0 {
+		return intPartPow, nil
+	}
+
+	// TODO: optimize NumDigits for more performant precision adjustment
+	digitsBase := d.NumDigits()
+	digitsExponent := d2.NumDigits()
+
+	if int32(digitsBase) > precision {
+		precision = int32(digitsBase)
+	}
+	if int32(digitsExponent) > precision {
+		precision += int32(digitsExponent)
+	}
+	// increase precision by 10 to compensate for errors in further calculations
+	precision += 10
+
+	// Calculate x ** frac(y), where
+	// x ** frac(y) = exp(ln(x ** frac(y)) = exp(ln(x) * frac(y))
+	fracPartPow, err := d.Abs().Ln(precision)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	fracPartPow = fracPartPow.Mul(expFracPart)
+
+	fracPartPow, err = fracPartPow.ExpTaylor(precision)
+	if err != nil {
+		return Decimal{}, err
+	}
+
+	// Join integer and fractional part,
+	// base ** (expBase + expFrac) = base ** expBase * base ** expFrac
+	res := intPartPow.Mul(fracPartPow)
+
+	return res, nil
+}
+
+// Ln returns the natural logarithm of d rounded to the precision of the context.
+// The result is approximated with guard digits, so it might be off by one unit
+// in the last place.
+//
+// The logarithm of a negative number signals InvalidOperation, the logarithm
+// of zero signals DivisionByZero and returns -Inf.
+func (c *Context) Ln(d Decimal) (Decimal, error) {
+	if d.form != formFinite && !d.IsInf(-1) {
+		return c.nonFinite(d, d)
+	}
+	if d.IsNegative() {
+		return c.fail(NaN(), InvalidOperation, "cannot calculate natural logarithm for negative decimals")
+	}
+	if d.IsZero() {
+		return c.fail(Inf(-1), DivisionByZero, "cannot represent natural logarithm of 0, result: -infinity")
+	}
+
+	one := New(1, 0)
+	if d.Equal(one) {
+		return New(0, 0), nil
+	}
+
+	if !c.Significant {
+		l, err := d.Ln(c.Precision + guardDigits)
+		if err != nil {
+			return Decimal{}, err
+		}
+		return c.Round(l)
+	}
+
+	// ln(d) is close to d - 1 in the neighbourhood of 1
+	var adj float64
+	if delta := d.Sub(one); delta.Abs().Cmp(New(1, -1)) < 0 {
+		adj = float64(delta.adjusted())
+	} else {
+		adj = math.Floor(math.Log10(math.Abs(log10Abs(d) * math.Ln10)))
+	}
+	return c.approximate(adj, d.Ln)
+}
+
+// approximate rounds the result of f, which approximates a non-zero value with
+// the given number of places after the decimal point, to Precision significant
+// digits. adj is an estimate of the exponent of the most significant digit of
+// the result, the number of places is increased while it proves to be too low.
+func (c *Context) approximate(adj float64, f func(places int32) (Decimal, error)) (Decimal, error) {
+	if c.Precision <= 0 {
+		return c.fail(NaN(), InvalidOperation, "approximation requires a positive precision")
+	}
+
+	places := float64(c.Precision) - 1 - adj + guardDigits
+	for i := 0; ; i++ {
+		if places > math.MaxInt32 || places < math.MinInt32 {
+			return c.fail(NaN(), Overflow, "overflow in decimal approximation")
+		}
+		r, err := f(int32(places))
+		if err != nil {
+			return Decimal{}, err
+		}
+
+		var need float64
+		if r.IsZero() {
+			need = places + float64(c.Precision) + guardDigits
+		} else {
+			need = float64(c.Precision) - 1 - float64(r.adjusted()) + guardDigits
+		}
+		if need <= places || i == 3 {
+			return c.Round(r)
+		}
+		places = need
+	}
+}
+
+// log10Abs returns an approximation of the decimal logarithm of abs(d),
+// which doesn't overflow for exponents beyond the range of float64.
+func log10Abs(d Decimal) float64 {
+	n := int32(d.NumDigits())
+	mantissa := d.Shift(1 - n - d.exp).InexactFloat64()
+	return float64(d.exp+n-1) + math.Log10(math.Abs(mantissa))
+}
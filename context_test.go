@@ -0,0 +1,545 @@
+package decimal
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestRoundingMode_roundMode(t *testing.T) {
+	inputs := []string{"5.5", "2.5", "1.6", "1.1", "1.0", "0.3", "-1.0", "-1.1", "-1.6", "-2.5", "-5.5"}
+	for _, testCase := range []struct {
+		Mode     RoundingMode
+		Expected []string
+	}{
+		{RoundHalfUp, []string{"6", "3", "2", "1", "1", "0", "-1", "-1", "-2", "-3", "-6"}},
+		{RoundHalfDown, []string{"5", "2", "2", "1", "1", "0", "-1", "-1", "-2", "-2", "-5"}},
+		{RoundHalfEven, []string{"6", "2", "2", "1", "1", "0", "-1", "-1", "-2", "-2", "-6"}},
+		{RoundHalfOdd, []string{"5", "3", "2", "1", "1", "0", "-1", "-1", "-2", "-3", "-5"}},
+		{RoundCeiling, []string{"6", "3", "2", "2", "1", "1", "-1", "-1", "-1", "-2", "-5"}},
+		{RoundFloor, []string{"5", "2", "1", "1", "1", "0", "-1", "-2", "-2", "-3", "-6"}},
+		{RoundUp, []string{"6", "3", "2", "2", "1", "1", "-1", "-2", "-2", "-3", "-6"}},
+		{RoundDown, []string{"5", "2", "1", "1", "1", "0", "-1", "-1", "-1", "-2", "-5"}},
+		{Round05Up, []string{"6", "2", "1", "1", "1", "1", "-1", "-1", "-1", "-2", "-6"}},
+	} {
+		for i, input := range inputs {
+			d := RequireFromString(input)
+			rounded, inexact := d.roundMode(0, testCase.Mode)
+			if rounded.String() != testCase.Expected[i] {
+				t.Errorf("expected %s, got %s, for %s rounded with mode %s", testCase.Expected[i], rounded, input, testCase.Mode)
+			}
+			if wantInexact := !d.IsInteger(); inexact != wantInexact {
+				t.Errorf("expected inexact %t, got %t, for %s rounded with mode %s", wantInexact, inexact, input, testCase.Mode)
+			}
+		}
+	}
+}
+
+func TestContext_Round(t *testing.T) {
+	for _, testCase := range []struct {
+		Dec         string
+		Precision   int32
+		Significant bool
+		Mode        RoundingMode
+		Expected    string
+		Inexact     bool
+	}{
+		{"123.456", 2, false, RoundHalfUp, "123.46", true},
+		{"123.456", 2, false, RoundDown, "123.45", true},
+		{"123.45", 5, false, RoundHalfUp, "123.45", false},
+		{"123.456", -1, false, RoundHalfUp, "1.2E2", true},
+		{"123.456", 4, true, RoundHalfUp, "123.5", true},
+		{"123.456", 2, true, RoundHalfEven, "1.2E2", true},
+		{"0.000123456", 3, true, RoundHalfUp, "0.000123", true},
+		{"9999.6", 4, true, RoundHalfUp, "1.000E4", true},
+		{"-9999.6", 4, true, RoundCeiling, "-9999", true},
+		{"1200", 2, true, RoundHalfUp, "1.2E3", false},
+		{"123.456", 0, true, RoundHalfUp, "123.456", false},
+	} {
+		ctx := Context{Precision: testCase.Precision, Significant: testCase.Significant, Rounding: testCase.Mode}
+		d := RequireFromString(testCase.Dec)
+
+		rounded, err := ctx.Round(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := rounded.string(false, false); s != testCase.Expected {
+			t.Errorf("expected %s, got %s, for %s rounded with %+v", testCase.Expected, s, testCase.Dec, ctx)
+		}
+		if inexact := ctx.Flags&Inexact != 0; inexact != testCase.Inexact {
+			t.Errorf("expected inexact %t, got %t, for %s rounded with %+v", testCase.Inexact, inexact, testCase.Dec, ctx)
+		}
+	}
+}
+
+func TestContext_AddSubMul(t *testing.T) {
+	ctx := Context{Precision: 5, Significant: true, Rounding: RoundHalfEven}
+
+	sum, err := ctx.Add(RequireFromString("99999"), RequireFromString("0.5"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum.String() != "100000" {
+		t.Errorf("expected 100000, got %s", sum)
+	}
+
+	diff, err := ctx.Sub(RequireFromString("1.000025"), RequireFromString("0.000001"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff.String() != "1" {
+		t.Errorf("expected 1, got %s", diff)
+	}
+
+	prod, err := ctx.Mul(RequireFromString("1.2345"), RequireFromString("1.5"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prod.String() != "1.8518" {
+		t.Errorf("expected 1.8518, got %s", prod)
+	}
+
+	if ctx.Flags != Inexact {
+		t.Errorf("expected flags %s, got %s", Inexact, ctx.Flags)
+	}
+}
+
+func TestContext_MulAdd(t *testing.T) {
+	ctx := Context{Precision: 2, Rounding: RoundHalfUp}
+	three, price, fee := New(3, 0), RequireFromString("0.335"), RequireFromString("0.005")
+
+	r, err := ctx.MulAdd(three, price, fee)
+	if err != nil || r.String() != "1.01" {
+		t.Errorf("expected 1.01, got %s and %v", r, err)
+	}
+	// rounding the product first rounds twice
+	p, _ := ctx.Mul(three, price)
+	if twice, _ := ctx.Add(p, fee); twice.String() != "1.02" {
+		t.Errorf("expected 1.02, got %s", twice)
+	}
+
+	r, err = ctx.MulSub(three, price, fee)
+	if err != nil || r.String() != "1" {
+		t.Errorf("expected 1, got %s and %v", r, err)
+	}
+
+	ctx = Context{Precision: 3, Significant: true, Rounding: RoundHalfEven}
+	r, err = ctx.MulSub(RequireFromString("1.0005"), RequireFromString("1.0005"), New(1, 0))
+	if err != nil || r.String() != "0.001" {
+		t.Errorf("expected 0.001, got %s and %v", r, err)
+	}
+	if ctx.Flags != Inexact {
+		t.Errorf("expected flags %s, got %s", Inexact, ctx.Flags)
+	}
+
+	ctx = Context{Precision: 2, Traps: DefaultTraps}
+	if r, err = ctx.MulAdd(Inf(1), New(-2, 0), New(1, 0)); err != nil || !r.IsInf(-1) {
+		t.Errorf("expected -Inf, got %s and %v", r, err)
+	}
+	if _, err = ctx.MulSub(Inf(1), New(2, 0), Inf(1)); err == nil {
+		t.Errorf("expected invalid operation error for Inf - Inf")
+	}
+	if r, err = ctx.MulAdd(NaN(), New(2, 0), New(1, 0)); err != nil || !r.IsNaN() {
+		t.Errorf("expected NaN, got %s and %v", r, err)
+	}
+	if _, err = ctx.MulAdd(New(1, math.MaxInt32), New(1, 1), New(1, 0)); err == nil {
+		t.Errorf("expected overflow error")
+	}
+}
+
+func TestContext_DotProduct(t *testing.T) {
+	ctx := Context{Precision: 2, Rounding: RoundHalfEven}
+	qty := []Decimal{New(3, 0), RequireFromString("1.5"), New(-2, 0)}
+	prices := []Decimal{RequireFromString("0.3333"), RequireFromString("1.1111"), RequireFromString("0.0025")}
+
+	// 0.9999 + 1.66665 - 0.005 = 2.66155
+	r, err := ctx.DotProduct(qty, prices)
+	if err != nil || r.String() != "2.66" {
+		t.Errorf("expected 2.66, got %s and %v", r, err)
+	}
+
+	if r, err = ctx.DotProduct(nil, nil); err != nil || r.String() != "0" {
+		t.Errorf("expected 0, got %s and %v", r, err)
+	}
+	if _, err = ctx.DotProduct(qty, prices[:2]); err == nil {
+		t.Errorf("expected error for slices of different lengths")
+	}
+
+	ctx = Context{Precision: 2}
+	r, err = ctx.DotProduct([]Decimal{New(1, math.MaxInt32), New(1, 0)}, []Decimal{New(1, 1), New(1, 0)})
+	if err != nil || !r.IsInf(1) || ctx.Flags != Overflow {
+		t.Errorf("expected Inf and overflow, got %s, %v and %s", r, err, ctx.Flags)
+	}
+}
+
+func TestContext_Div(t *testing.T) {
+	for _, testCase := range []struct {
+		Dividend    string
+		Divisor     string
+		Precision   int32
+		Significant bool
+		Mode        RoundingMode
+		Expected    string
+		Inexact     bool
+	}{
+		{"2", "3", 4, false, RoundHalfUp, "0.6667", true},
+		{"2", "3", 4, false, RoundDown, "0.6666", true},
+		{"-2", "3", 4, false, RoundFloor, "-0.6667", true},
+		{"-2", "3", 4, false, RoundCeiling, "-0.6666", true},
+		{"1", "8", 2, false, RoundHalfEven, "0.12", true},
+		{"3", "8", 2, false, RoundHalfEven, "0.38", true},
+		{"10", "4", 3, false, RoundHalfUp, "2.500", false},
+		{"2", "3", 4, true, RoundHalfUp, "0.6667", true},
+		{"20000", "3", 4, true, RoundHalfUp, "6667", true},
+		{"2", "30000", 4, true, RoundHalfUp, "0.00006667", true},
+		{"9.9999", "1", 4, true, RoundHalfUp, "10.00", true},
+		{"10", "4", 10, true, RoundHalfUp, "2.5", false},
+		{"1200", "4", 10, true, RoundHalfUp, "300", false},
+		{"1.00", "4", 10, true, RoundHalfUp, "0.25", false},
+		{"0", "7", 10, true, RoundHalfUp, "0", false},
+		{"-1", "7", 3, true, RoundHalfOdd, "-0.143", true},
+	} {
+		ctx := Context{Precision: testCase.Precision, Significant: testCase.Significant, Rounding: testCase.Mode}
+		d := RequireFromString(testCase.Dividend)
+		d2 := RequireFromString(testCase.Divisor)
+
+		q, err := ctx.Div(d, d2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := q.string(false, true); s != testCase.Expected {
+			t.Errorf("expected %s, got %s, for %s / %s with %+v", testCase.Expected, s, testCase.Dividend, testCase.Divisor, ctx)
+		}
+		if inexact := ctx.Flags&Inexact != 0; inexact != testCase.Inexact {
+			t.Errorf("expected inexact %t, got %t, for %s / %s with %+v", testCase.Inexact, inexact, testCase.Dividend, testCase.Divisor, ctx)
+		}
+	}
+}
+
+func TestContext_DivRoundWrapper(t *testing.T) {
+	for _, inp := range createDivTestCases() {
+		if inp.d2.IsZero() {
+			continue
+		}
+		ctx := Context{Precision: inp.prec}
+		q, err := ctx.Div(inp.d, inp.d2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := inp.d.DivRound(inp.d2, inp.prec); !q.Equal(expected) || q.exp != expected.exp {
+			t.Errorf("expected %s, got %s, for %s / %s with precision %d", expected, q, inp.d, inp.d2, inp.prec)
+		}
+	}
+}
+
+func TestContext_Traps(t *testing.T) {
+	ctx := Context{Precision: 2, Traps: DefaultTraps}
+
+	_, err := ctx.Div(New(1, 0), New(0, 0))
+	if err == nil {
+		t.Fatalf("expected division by zero error")
+	}
+	if cerr, ok := err.(*ConditionError); !ok || cerr.Condition != DivisionByZero {
+		t.Errorf("expected *ConditionError with %s, got %#v", DivisionByZero, err)
+	}
+	if err.Error() != "decimal division by 0" {
+		t.Errorf("unexpected error message %q", err.Error())
+	}
+	if cerr := err.(*ConditionError); !cerr.Is(ErrDivisionByZero) || cerr.Is(ErrExponentOverflow) {
+		t.Errorf("expected %v to match only ErrDivisionByZero", err)
+	}
+
+	_, err = ctx.Div(New(0, 0), New(0, 0))
+	if cerr, ok := err.(*ConditionError); !ok || cerr.Condition != InvalidOperation {
+		t.Errorf("expected *ConditionError with %s, got %#v", InvalidOperation, err)
+	}
+
+	_, err = ctx.Mul(New(1, math.MaxInt32), New(1, 1))
+	if cerr, ok := err.(*ConditionError); !ok || cerr.Condition != Overflow {
+		t.Errorf("expected *ConditionError with %s, got %#v", Overflow, err)
+	}
+	if cerr := err.(*ConditionError); !cerr.Is(ErrExponentOverflow) || cerr.Is(ErrDivisionByZero) {
+		t.Errorf("expected %v to match only ErrExponentOverflow", err)
+	}
+
+	// inexact results aren't trapped by default
+	q, err := ctx.Div(New(1, 0), New(3, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.String() != "0.33" {
+		t.Errorf("expected 0.33, got %s", q)
+	}
+
+	if want := DivisionByZero | InvalidOperation | Overflow | Inexact; ctx.Flags != want {
+		t.Errorf("expected flags %s, got %s", want, ctx.Flags)
+	}
+
+	ctx.Traps |= Inexact
+	if _, err = ctx.Div(New(1, 0), New(3, 0)); err == nil || err.Error() != "inexact" {
+		t.Errorf("expected inexact error, got %v", err)
+	}
+	if _, err = ctx.Div(New(1, 0), New(4, 0)); err != nil {
+		t.Errorf("expected no error for an exact division, got %v", err)
+	}
+
+	// untrapped conditions return NaN or an infinity
+	ctx = Context{Precision: 2}
+	q, err = ctx.Div(New(-1, 0), New(0, 0))
+	if err != nil || !q.IsInf(-1) {
+		t.Errorf("expected -Inf and no error, got %s and %v", q, err)
+	}
+	if ctx.Flags != DivisionByZero {
+		t.Errorf("expected flags %s, got %s", DivisionByZero, ctx.Flags)
+	}
+	q, err = ctx.Div(New(0, 0), New(0, 0))
+	if err != nil || !q.IsNaN() {
+		t.Errorf("expected NaN and no error, got %s and %v", q, err)
+	}
+	q, err = ctx.Mul(New(1, math.MaxInt32), New(-1, 1))
+	if err != nil || !q.IsInf(-1) {
+		t.Errorf("expected -Inf and no error, got %s and %v", q, err)
+	}
+	q, err = ctx.Ln(New(0, 0))
+	if err != nil || !q.IsInf(-1) {
+		t.Errorf("expected -Inf and no error, got %s and %v", q, err)
+	}
+}
+
+func TestContext_SpecialValues(t *testing.T) {
+	for _, testCase := range []struct {
+		Op       string
+		D        string
+		D2       string
+		Expected string
+		Invalid  bool
+	}{
+		{"add", "Inf", "1", "Inf", false},
+		{"add", "Inf", "-Inf", "NaN", true},
+		{"add", "NaN", "1", "NaN", false},
+		{"add", "sNaN", "1", "NaN", true},
+		{"sub", "Inf", "Inf", "NaN", true},
+		{"sub", "1", "Inf", "-Inf", false},
+		{"mul", "-Inf", "2", "-Inf", false},
+		{"mul", "Inf", "0", "NaN", true},
+		{"div", "Inf", "-2", "-Inf", false},
+		{"div", "2", "Inf", "0", false},
+		{"div", "Inf", "Inf", "NaN", true},
+		{"div", "NaN", "0", "NaN", false},
+		{"pow", "Inf", "-1", "0", false},
+		{"pow", "-Inf", "3", "-Inf", false},
+		{"pow", "0.5", "Inf", "0", false},
+		{"pow", "sNaN", "0", "NaN", true},
+	} {
+		d := RequireFromString(testCase.D)
+		d2 := RequireFromString(testCase.D2)
+		ctx := Context{Precision: 4}
+		var res Decimal
+		var err error
+		switch testCase.Op {
+		case "add":
+			res, err = ctx.Add(d, d2)
+		case "sub":
+			res, err = ctx.Sub(d, d2)
+		case "mul":
+			res, err = ctx.Mul(d, d2)
+		case "div":
+			res, err = ctx.Div(d, d2)
+		case "pow":
+			res, err = ctx.Pow(d, d2)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.String() != testCase.Expected {
+			t.Errorf("expected %s, got %s, for %s %s %s", testCase.Expected, res, d, testCase.Op, d2)
+		}
+		if invalid := ctx.Flags&InvalidOperation != 0; invalid != testCase.Invalid {
+			t.Errorf("expected invalid operation %v, got flags %s, for %s %s %s", testCase.Invalid, ctx.Flags, d, testCase.Op, d2)
+		}
+
+		ctx = Context{Precision: 4, Traps: DefaultTraps}
+		_, err = ctx.Add(d, d2)
+		if _, ok := err.(*ConditionError); testCase.Op == "add" && testCase.Invalid && !ok {
+			t.Errorf("expected *ConditionError, got %v, for %s + %s", err, d, d2)
+		}
+	}
+}
+
+func TestContext_Pow(t *testing.T) {
+	for _, testCase := range []struct {
+		Base        string
+		Exponent    string
+		Precision   int32
+		Significant bool
+		Mode        RoundingMode
+		Expected    string
+	}{
+		{"3.13", "5", 4, false, RoundHalfUp, "300.4151"},
+		{"3.13", "5", 4, false, RoundDown, "300.415"},
+		{"3.13", "5", 4, true, RoundHalfUp, "300.4"},
+		{"3", "-6", 10, false, RoundHalfUp, "0.0013717421"},
+		{"3", "-6", 10, true, RoundHalfUp, "0.001371742112"},
+		{"3", "-6", 10, true, RoundDown, "0.001371742112"},
+		{"5", "5.73", 8, false, RoundHalfUp, "10118.0803716"},
+		{"5", "5.73", 8, true, RoundHalfUp, "10118.080"},
+		{"0.001", "10.5", 5, true, RoundHalfUp, "3.1623E-32"},
+		{"0", "2.5", 5, true, RoundHalfUp, "0"},
+	} {
+		ctx := Context{Precision: testCase.Precision, Significant: testCase.Significant, Rounding: testCase.Mode, Traps: DefaultTraps}
+		base := RequireFromString(testCase.Base)
+		exp := RequireFromString(testCase.Exponent)
+		expected := RequireFromString(testCase.Expected)
+
+		p, err := ctx.Pow(base, exp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !p.Equal(expected) {
+			t.Errorf("expected %s, got %s, for %s^%s with %+v", testCase.Expected, p, testCase.Base, testCase.Exponent, ctx)
+		}
+	}
+
+	for _, testCase := range []struct {
+		Base      string
+		Exponent  string
+		Condition Condition
+	}{
+		{"0", "0", InvalidOperation},
+		{"0", "-2", DivisionByZero},
+		{"0", "-2.5", DivisionByZero},
+		{"-2", "0.5", InvalidOperation},
+	} {
+		ctx := Context{Precision: 5, Significant: true, Traps: DefaultTraps}
+		_, err := ctx.Pow(RequireFromString(testCase.Base), RequireFromString(testCase.Exponent))
+		if cerr, ok := err.(*ConditionError); !ok || cerr.Condition != testCase.Condition {
+			t.Errorf("expected *ConditionError with %s, got %#v, for %s^%s", testCase.Condition, err, testCase.Base, testCase.Exponent)
+		}
+	}
+}
+
+func TestContext_Ln(t *testing.T) {
+	for _, testCase := range []struct {
+		Dec         string
+		Precision   int32
+		Significant bool
+		Expected    string
+	}{
+		{"3.13", 10, false, "1.1410330046"},
+		{"3.13", 10, true, "1.141033005"},
+		{"1.0001", 10, true, "0.00009999500033"},
+		{"0.00000001", 5, true, "-18.421"},
+		{"5023583755703750094849.03519358513093500275017501750602739169823", 5, true, "49.968"},
+		{"1", 5, true, "0"},
+	} {
+		ctx := Context{Precision: testCase.Precision, Significant: testCase.Significant, Traps: DefaultTraps}
+		d := RequireFromString(testCase.Dec)
+		expected := RequireFromString(testCase.Expected)
+
+		ln, err := ctx.Ln(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ln.Equal(expected) {
+			t.Errorf("expected %s, got %s, for ln(%s) with %+v", testCase.Expected, ln, testCase.Dec, ctx)
+		}
+	}
+
+	ctx := Context{Precision: 5, Traps: DefaultTraps}
+	if _, err := ctx.Ln(New(0, 0)); err == nil {
+		t.Errorf("expected error for ln(0)")
+	}
+	if _, err := ctx.Ln(New(-1, 0)); err == nil {
+		t.Errorf("expected error for ln(-1)")
+	}
+}
+
+func TestCondition_String(t *testing.T) {
+	for _, testCase := range []struct {
+		Condition Condition
+		Expected  string
+	}{
+		{0, "no conditions"},
+		{Inexact, "inexact"},
+		{DivisionByZero | InvalidOperation, "division by zero, invalid operation"},
+		{DefaultTraps | Inexact, "division by zero, inexact, overflow, invalid operation"},
+	} {
+		if s := testCase.Condition.String(); s != testCase.Expected {
+			t.Errorf("expected %q, got %q", testCase.Expected, s)
+		}
+	}
+}
+
+func TestRoundingMode_String(t *testing.T) {
+	for _, testCase := range []struct {
+		Mode     RoundingMode
+		Expected string
+	}{
+		{RoundHalfUp, "HalfUp"},
+		{RoundHalfEven, "HalfEven"},
+		{Round05Up, "05Up"},
+		{RoundingMode(42), "RoundingMode(42)"},
+	} {
+		if s := testCase.Mode.String(); s != testCase.Expected {
+			t.Errorf("expected %q, got %q", testCase.Expected, s)
+		}
+	}
+}
+
+func TestParseRoundingMode(t *testing.T) {
+	for _, testCase := range []struct {
+		Name     string
+		Expected RoundingMode
+	}{
+		{"HalfUp", RoundHalfUp},
+		{"half_down", RoundHalfDown},
+		{"ROUND_HALF_EVEN", RoundHalfEven},
+		{"round-half-odd", RoundHalfOdd},
+		{"Ceiling", RoundCeiling},
+		{"FLOOR", RoundFloor},
+		{"up", RoundUp},
+		{"RoundDown", RoundDown},
+		{"ROUND_05UP", Round05Up},
+	} {
+		mode, err := ParseRoundingMode(testCase.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mode != testCase.Expected {
+			t.Errorf("expected %s, got %s, for %q", testCase.Expected, mode, testCase.Name)
+		}
+	}
+
+	for _, name := range []string{"", "half", "nearest", "HalfUpp"} {
+		if _, err := ParseRoundingMode(name); err == nil {
+			t.Errorf("expected error for %q", name)
+		}
+	}
+}
+
+func TestRoundingMode_Text(t *testing.T) {
+	var config struct {
+		Mode RoundingMode `json:"mode"`
+	}
+	if err := json.Unmarshal([]byte(`{"mode": "half_even"}`), &config); err != nil {
+		t.Fatal(err)
+	}
+	if config.Mode != RoundHalfEven {
+		t.Errorf("expected %s, got %s", RoundHalfEven, config.Mode)
+	}
+
+	b, err := json.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"mode":"HalfEven"}` {
+		t.Errorf("unexpected JSON %s", b)
+	}
+
+	if err := json.Unmarshal([]byte(`{"mode": "sideways"}`), &config); err == nil {
+		t.Errorf("expected error for unknown rounding mode")
+	}
+	if _, err := RoundingMode(42).MarshalText(); err == nil {
+		t.Errorf("expected error for invalid rounding mode")
+	}
+}
@@ -19,6 +19,7 @@ package decimal
 import (
 	"database/sql/driver"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math"
 	"math/big"
@@ -86,6 +87,25 @@ var ExpMaxIterations = 1000
 // Zero should never be compared with == or != directly, please use decimal.Equal or decimal.Cmp instead.
 var Zero = New(0, 1)
 
+// Errors returned by the checked arithmetic methods, e.g. DivE and QuoRemE.
+// The errors of a Context for trapped DivisionByZero and Overflow conditions
+// match ErrDivisionByZero and ErrExponentOverflow with errors.Is.
+var (
+	// ErrDivisionByZero is returned when the divisor is zero.
+	ErrDivisionByZero = errors.New("decimal division by 0")
+	// ErrExponentOverflow is returned when the exponent of a result doesn't fit into an int32.
+	ErrExponentOverflow = errors.New("decimal exponent overflows an int32")
+	// ErrNonTerminating is returned by DivExact when the quotient has no terminating decimal expansion.
+	ErrNonTerminating = errors.New("decimal quotient has no terminating decimal expansion")
+	// ErrTooManyDigits is returned by QuantizeE when the coefficient of the result has too many digits.
+	ErrTooManyDigits = errors.New("decimal result has too many digits")
+	// ErrExpTooManyDigits is returned by Exp and the functions computed with it
+	// when the result would have more than 100000 integer digits.
+	ErrExpTooManyDigits = errors.New("decimal exponential has more than 100000 integer digits")
+	// ErrInvalidInterval is returned for a cash rounding interval other than 5, 10, 25, 50 or 100.
+	ErrInvalidInterval = errors.New("decimal does not support this cash rounding interval")
+)
+
 var zeroInt = big.NewInt(0)
 var oneInt = big.NewInt(1)
 var twoInt = big.NewInt(2)
@@ -98,22 +118,73 @@ var factorials = []Decimal{New(1, 0)}
 
 // Decimal represents a fixed-point decimal. It is immutable.
 // number = value * 10 ^ exp
+//
+// Besides finite numbers, a Decimal can hold the special values NaN and ±Inf,
+// which follow the IEEE 754 rules, see NaN and Inf. A negative zero parsed by
+// NewFromString keeps its sign internally, as the divisor or quotient of Div,
+// in Float64 and in the binary encoding, see Signbit. It's formatted as "0"
+// like any zero, and other operations, rounding included, treat it as zero.
 type Decimal struct {
+	// value is the coefficient of numbers whose coefficient doesn't fit into
+	// an int64, and nil otherwise.
 	value *big.Int
 
+	// compact is the coefficient when value is nil, which saves the
+	// allocation and arithmetic of a big.Int for most numbers.
+	compact int64
+
 	// NOTE(vadim): this must be an int32, because we cast it to float64 during
 	// calculations. If exp is 64 bit, we might lose precision.
 	// If we cared about being able to represent every possible decimal, we
 	// could make exp a *big.Int but it would hurt performance and numbers
 	// like that are unrealistic.
 	exp int32
+
+	// form tells finite numbers apart from the special values, for which
+	// value and exp are unused.
+	form form
+
+	// neg is the sign of an infinity or of a zero, which value can't carry.
+	neg bool
+}
+
+// form is the kind of number held by a Decimal.
+type form uint8
+
+const (
+	formFinite form = iota
+	formInfinite
+	formNaN
+	formSNaN
+)
+
+// NaN returns a quiet NaN (not-a-number), the result of undefined operations
+// like 0/0 or Inf-Inf.
+//
+// A NaN propagates through arithmetic and is never equal to any Decimal,
+// including itself. Cmp orders it before every other value.
+func NaN() Decimal {
+	return Decimal{form: formNaN}
+}
+
+// SignalingNaN returns a signaling NaN.
+//
+// A signaling NaN behaves like a quiet NaN, except that operations of a Context
+// signal InvalidOperation when one of their operands is a signaling NaN.
+func SignalingNaN() Decimal {
+	return Decimal{form: formSNaN}
+}
+
+// Inf returns positive infinity if sign >= 0, negative infinity if sign < 0.
+func Inf(sign int) Decimal {
+	return Decimal{form: formInfinite, neg: sign < 0}
 }
 
 // New returns a new fixed-point decimal, value * 10 ^ exp.
 func New(value int64, exp int32) Decimal {
 	return Decimal{
-		value: big.NewInt(value),
-		exp:   exp,
+		compact: value,
+		exp:     exp,
 	}
 }
 
@@ -125,8 +196,8 @@ func New(value int64, exp int32) Decimal {
 //	NewFromInt(-10).String() // output: "-10"
 func NewFromInt(value int64) Decimal {
 	return Decimal{
-		value: big.NewInt(value),
-		exp:   0,
+		compact: value,
+		exp:     0,
 	}
 }
 
@@ -138,8 +209,8 @@ func NewFromInt(value int64) Decimal {
 //	NewFromInt(-10).String() // output: "-10"
 func NewFromInt32(value int32) Decimal {
 	return Decimal{
-		value: big.NewInt(int64(value)),
-		exp:   0,
+		compact: int64(value),
+		exp:     0,
 	}
 }
 
@@ -149,6 +220,9 @@ func NewFromInt32(value int32) Decimal {
 //
 //	NewFromUint64(123).String() // output: "123"
 func NewFromUint64(value uint64) Decimal {
+	if value <= math.MaxInt64 {
+		return NewFromInt(int64(value))
+	}
 	return Decimal{
 		value: new(big.Int).SetUint64(value),
 		exp:   0,
@@ -157,6 +231,9 @@ func NewFromUint64(value uint64) Decimal {
 
 // NewFromBigInt returns a new Decimal from a big.Int, value * 10 ^ exp
 func NewFromBigInt(value *big.Int, exp int32) Decimal {
+	if value.IsInt64() {
+		return New(value.Int64(), exp)
+	}
 	return Decimal{
 		value: new(big.Int).Set(value),
 		exp:   exp,
@@ -173,24 +250,27 @@ func NewFromBigInt(value *big.Int, exp int32) Decimal {
 //	d3 := NewFromBigRat(big.NewRat(1000, 3), 3) // output: "333.333"
 //	d4 := NewFromBigRat(big.NewRat(2, 7), 4)    // output: "0.2857"
 func NewFromBigRat(value *big.Rat, precision int32) Decimal {
-	return Decimal{
-		value: new(big.Int).Set(value.Num()),
-		exp:   0,
-	}.DivRound(Decimal{
-		value: new(big.Int).Set(value.Denom()),
-		exp:   0,
-	}, precision)
+	return NewFromBigInt(value.Num(), 0).DivRound(NewFromBigInt(value.Denom(), 0), precision)
 }
 
 // NewFromString returns a new Decimal from a string representation.
 // Trailing zeroes are not trimmed.
 //
+// The special values are accepted case-insensitively as "NaN", "sNaN",
+// "Inf" and "Infinity", the latter two with an optional sign. A "-0" keeps
+// its sign internally, see Signbit.
+//
 // Example:
 //
 //	d, err := NewFromString("-123.45")
 //	d2, err := NewFromString(".0001")
 //	d3, err := NewFromString("1.47000")
+//	d4, err := NewFromString("-Infinity")
 func NewFromString(value string) (Decimal, error) {
+	if d, ok := parseSpecial(value); ok {
+		return d, nil
+	}
+
 	originalInput := value
 	var intString string
 	var exp int64
@@ -242,19 +322,23 @@ func NewFromString(value string) (Decimal, error) {
 	}
 
 	var dValue *big.Int
+	var compact int64
 	// strconv.ParseInt is faster than new(big.Int).SetString so this is just a shortcut for strings we know won't overflow
 	if len(intString) <= 18 {
 		parsed64, err := strconv.ParseInt(intString, 10, 64)
 		if err != nil {
 			return Decimal{}, fmt.Errorf("can't convert %s to decimal", value)
 		}
-		dValue = big.NewInt(parsed64)
+		compact = parsed64
 	} else {
 		dValue = new(big.Int)
 		_, ok := dValue.SetString(intString, 10)
 		if !ok {
 			return Decimal{}, fmt.Errorf("can't convert %s to decimal", value)
 		}
+		if dValue.IsInt64() {
+			compact, dValue = dValue.Int64(), nil
+		}
 	}
 
 	if exp < math.MinInt32 || exp > math.MaxInt32 {
@@ -263,11 +347,37 @@ func NewFromString(value string) (Decimal, error) {
 	}
 
 	return Decimal{
-		value: dValue,
-		exp:   int32(exp),
+		value:   dValue,
+		compact: compact,
+		exp:     int32(exp),
+		neg:     dValue == nil && compact == 0 && value[0] == '-',
 	}, nil
 }
 
+// parseSpecial parses the string representation of NaN, sNaN or a signed
+// infinity. It reports false for any other string.
+func parseSpecial(value string) (Decimal, bool) {
+	neg := false
+	if len(value) > 0 && (value[0] == '-' || value[0] == '+') {
+		neg = value[0] == '-'
+		value = value[1:]
+	}
+	// cheap check to keep numbers off strings.ToLower
+	if len(value) < 3 || len(value) > 8 || value[0] < 'A' {
+		return Decimal{}, false
+	}
+
+	switch strings.ToLower(value) {
+	case "inf", "infinity":
+		return Decimal{form: formInfinite, neg: neg}, true
+	case "nan":
+		return NaN(), true
+	case "snan":
+		return SignalingNaN(), true
+	}
+	return Decimal{}, false
+}
+
 // NewFromFormattedString returns a new Decimal from a formatted string representation.
 // The second argument - replRegexp, is a regular expression that is used to find characters that should be
 // removed from given decimal string representation. All matched characters will be replaced with an empty string.
@@ -377,12 +487,12 @@ func newFromFloat(val float64, bits uint64, flt *floatInfo) Decimal {
 		if d.neg {
 			tmp *= -1
 		}
-		return Decimal{value: big.NewInt(tmp), exp: int32(d.dp) - int32(d.nd)}
+		return New(tmp, int32(d.dp)-int32(d.nd))
 	}
 	dValue := new(big.Int)
 	dValue, ok := dValue.SetString(string(d.d[:d.nd]), 10)
 	if ok {
-		return Decimal{value: dValue, exp: int32(d.dp) - int32(d.nd)}
+		return newDecimal(dValue, int32(d.dp)-int32(d.nd))
 	}
 
 	return NewFromFloatWithExponent(val, int32(d.dp)-int32(d.nd))
@@ -468,18 +578,18 @@ func NewFromFloatWithExponent(value float64, exp int32) Decimal {
 		dMant = dMant.Neg(dMant)
 	}
 
-	return Decimal{
-		value: dMant,
-		exp:   exp,
-	}
+	return newDecimal(dMant, exp)
 }
 
 // Copy returns a copy of decimal with the same value and exponent, but a different pointer to value.
 func (d Decimal) Copy() Decimal {
-	d.ensureInitialized()
+	if d.form != formFinite || d.value == nil {
+		return d
+	}
 	return Decimal{
 		value: new(big.Int).Set(d.value),
 		exp:   d.exp,
+		neg:   d.neg,
 	}
 }
 
@@ -503,18 +613,35 @@ func (d Decimal) Copy() Decimal {
 //	1.2
 //	1.2000
 func (d Decimal) rescale(exp int32) Decimal {
-	d.ensureInitialized()
+	if d.form != formFinite {
+		return d
+	}
 
 	if d.exp == exp {
 		return Decimal{
-			new(big.Int).Set(d.value),
-			d.exp,
+			value:   d.value,
+			compact: d.compact,
+			exp:     d.exp,
+		}
+	}
+
+	if d.value == nil {
+		// NOTE(vadim): must convert exps to int64 before - to prevent overflow
+		diff := int64(exp) - int64(d.exp)
+		if diff > maxPow10Int64 {
+			return New(0, exp)
+		}
+		if diff > 0 {
+			return New(d.compact/int64(pow10Uint64[diff]), exp)
+		}
+		if c, ok := mulPow10(d.compact, -diff); ok {
+			return New(c, exp)
 		}
 	}
 
 	// NOTE(vadim): must convert exps to float64 before - to prevent overflow
 	diff := math.Abs(float64(exp) - float64(d.exp))
-	value := new(big.Int).Set(d.value)
+	value := new(big.Int).Set(d.coef())
 
 	expScale := new(big.Int).Exp(tenInt, big.NewInt(int64(diff)), nil)
 	if exp > d.exp {
@@ -523,61 +650,99 @@ func (d Decimal) rescale(exp int32) Decimal {
 		value = value.Mul(value, expScale)
 	}
 
-	return Decimal{
-		value: value,
-		exp:   exp,
-	}
+	return newDecimal(value, exp)
 }
 
 // Abs returns the absolute value of the decimal.
 func (d Decimal) Abs() Decimal {
-	if !d.IsNegative() {
+	if d.form != formFinite || d.neg {
+		d.neg = false
 		return d
 	}
-	d.ensureInitialized()
-	d2Value := new(big.Int).Abs(d.value)
-	return Decimal{
-		value: d2Value,
-		exp:   d.exp,
+	if !d.IsNegative() {
+		return d
 	}
+	return d.Neg()
 }
 
 // Add returns d + d2.
+//
+// The sum of infinities of opposite signs is NaN.
 func (d Decimal) Add(d2 Decimal) Decimal {
+	if d.form != formFinite || d2.form != formFinite {
+		return addSpecial(d, d2, false)
+	}
+	if d.value == nil && d2.value == nil {
+		if a, b, exp, ok := rescalePair64(d, d2); ok {
+			if c, ok := add64(a, b); ok {
+				return New(c, exp)
+			}
+		}
+	}
 	rd, rd2 := RescalePair(d, d2)
 
-	d3Value := new(big.Int).Add(rd.value, rd2.value)
-	return Decimal{
-		value: d3Value,
-		exp:   rd.exp,
-	}
+	d3Value := new(big.Int).Add(rd.coef(), rd2.coef())
+	return newDecimal(d3Value, rd.exp)
 }
 
 // Sub returns d - d2.
 func (d Decimal) Sub(d2 Decimal) Decimal {
+	if d.form != formFinite || d2.form != formFinite {
+		return addSpecial(d, d2, true)
+	}
+	if d.value == nil && d2.value == nil {
+		if a, b, exp, ok := rescalePair64(d, d2); ok {
+			if c, ok := sub64(a, b); ok {
+				return New(c, exp)
+			}
+		}
+	}
 	rd, rd2 := RescalePair(d, d2)
 
-	d3Value := new(big.Int).Sub(rd.value, rd2.value)
-	return Decimal{
-		value: d3Value,
-		exp:   rd.exp,
+	d3Value := new(big.Int).Sub(rd.coef(), rd2.coef())
+	return newDecimal(d3Value, rd.exp)
+}
+
+// addSpecial returns d + d2, or d - d2 if sub is set, when d or d2 isn't finite.
+func addSpecial(d, d2 Decimal, sub bool) Decimal {
+	if d.IsNaN() || d2.IsNaN() {
+		return NaN()
 	}
+	neg2 := d2.neg != sub
+	if d.form == formInfinite {
+		if d2.form == formInfinite && d.neg != neg2 {
+			return NaN()
+		}
+		return d
+	}
+	return Decimal{form: formInfinite, neg: neg2}
 }
 
 // Neg returns -d.
+//
+// The negation of a zero is a positive zero.
 func (d Decimal) Neg() Decimal {
-	d.ensureInitialized()
-	val := new(big.Int).Neg(d.value)
-	return Decimal{
-		value: val,
-		exp:   d.exp,
+	if d.form == formInfinite {
+		d.neg = !d.neg
+		return d
 	}
+	if d.form != formFinite {
+		return d
+	}
+	if d.value == nil && d.compact != math.MinInt64 {
+		return New(-d.compact, d.exp)
+	}
+	val := new(big.Int).Neg(d.coef())
+	return newDecimal(val, d.exp)
 }
 
 // Mul returns d * d2.
+//
+// The product of an infinity and a zero is NaN.
 func (d Decimal) Mul(d2 Decimal) Decimal {
-	d.ensureInitialized()
-	d2.ensureInitialized()
+	if d.form != formFinite || d2.form != formFinite {
+		return mulSpecial(d, d2)
+	}
 
 	expInt64 := int64(d.exp) + int64(d2.exp)
 	if expInt64 > math.MaxInt32 || expInt64 < math.MinInt32 {
@@ -586,11 +751,22 @@ func (d Decimal) Mul(d2 Decimal) Decimal {
 		panic(fmt.Sprintf("exponent %v overflows an int32!", expInt64))
 	}
 
-	d3Value := new(big.Int).Mul(d.value, d2.value)
-	return Decimal{
-		value: d3Value,
-		exp:   int32(expInt64),
+	if d.value == nil && d2.value == nil {
+		if c, ok := mul64(d.compact, d2.compact); ok {
+			return New(c, int32(expInt64))
+		}
+	}
+
+	d3Value := new(big.Int).Mul(d.coef(), d2.coef())
+	return newDecimal(d3Value, int32(expInt64))
+}
+
+// mulSpecial returns d * d2 when d or d2 isn't finite.
+func mulSpecial(d, d2 Decimal) Decimal {
+	if d.IsNaN() || d2.IsNaN() || d.IsZero() || d2.IsZero() {
+		return NaN()
 	}
+	return Decimal{form: formInfinite, neg: d.Signbit() != d2.Signbit()}
 }
 
 // Shift shifts the decimal in base 10.
@@ -598,19 +774,134 @@ func (d Decimal) Mul(d2 Decimal) Decimal {
 // In simpler terms, the given value for shift is added to the exponent
 // of the decimal.
 func (d Decimal) Shift(shift int32) Decimal {
-	d.ensureInitialized()
+	if d.form != formFinite {
+		return d
+	}
 	return Decimal{
-		value: new(big.Int).Set(d.value),
-		exp:   d.exp + shift,
+		value:   d.value,
+		compact: d.compact,
+		exp:     d.exp + shift,
+	}
+}
+
+// ShiftE is like Shift, but returns ErrExponentOverflow instead of wrapping
+// around when the exponent of the result doesn't fit into an int32.
+func (d Decimal) ShiftE(shift int32) (Decimal, error) {
+	if exp := int64(d.exp) + int64(shift); d.form == formFinite && (exp > math.MaxInt32 || exp < math.MinInt32) {
+		return Decimal{}, ErrExponentOverflow
 	}
+	return d.Shift(shift), nil
 }
 
 // Div returns d / d2. If it doesn't divide exactly, the result will have
 // DivisionPrecision digits after the decimal point.
+//
+// Dividing a non-zero number by zero returns an infinity, 0/0 returns NaN.
 func (d Decimal) Div(d2 Decimal) Decimal {
 	return d.DivRound(d2, int32(DivisionPrecision))
 }
 
+// DivE is like Div, but returns ErrDivisionByZero if d2 is zero and
+// ErrExponentOverflow if the scale of the division doesn't fit into an int32,
+// instead of an infinity, NaN or a panic.
+//
+// Example:
+//
+//	_, err := NewFromInt(1).DivE(Zero)
+//	errors.Is(err, ErrDivisionByZero) // output: true
+func (d Decimal) DivE(d2 Decimal) (Decimal, error) {
+	if d2.IsZero() {
+		return Decimal{}, ErrDivisionByZero
+	}
+	ctx := Context{Precision: int32(DivisionPrecision), Rounding: RoundHalfUp, Traps: Overflow}
+	q, err := ctx.Div(d, d2)
+	if err != nil {
+		return Decimal{}, ErrExponentOverflow
+	}
+	return q, nil
+}
+
+// DivExact returns d / d2 without rounding. It returns ErrNonTerminating when the
+// quotient has no terminating decimal expansion, i.e. when the denominator of the
+// reduced fraction d / d2 has prime factors other than 2 and 5, and
+// ErrDivisionByZero if d2 is zero.
+//
+// Example:
+//
+//	NewFromInt(10).DivExact(NewFromInt(4)) // output: "2.5", nil
+//	NewFromInt(10).DivExact(NewFromInt(3)) // output: ErrNonTerminating
+func (d Decimal) DivExact(d2 Decimal) (Decimal, error) {
+	if d.form != formFinite || d2.form != formFinite {
+		return quoSpecial(d, d2), nil
+	}
+	if d2.Sign() == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+
+	num := new(big.Int).Set(d.coef())
+	den := new(big.Int).Abs(d2.coef())
+	if d2.Sign() < 0 {
+		num.Neg(num)
+	}
+	if num.Sign() == 0 {
+		den.SetInt64(1)
+	} else {
+		gcd := new(big.Int).GCD(nil, nil, new(big.Int).Abs(num), den)
+		num.Quo(num, gcd)
+		den.Quo(den, gcd)
+	}
+
+	// the quotient terminates if den = 2^twos * 5^fives, in which case
+	// num / den = num * 2^(k - twos) * 5^(k - fives) / 10^k with k = max(twos, fives)
+	var twos, fives int64
+	for den.Bit(0) == 0 {
+		den.Rsh(den, 1)
+		twos++
+	}
+	var q, r big.Int
+	for {
+		q.QuoRem(den, fiveInt, &r)
+		if r.Sign() != 0 {
+			break
+		}
+		den.Set(&q)
+		fives++
+	}
+	if den.Cmp(oneInt) != 0 {
+		return Decimal{}, ErrNonTerminating
+	}
+
+	k := twos
+	if fives > k {
+		k = fives
+	}
+	exp := int64(d.exp) - int64(d2.exp) - k
+	if exp > math.MaxInt32 || exp < math.MinInt32 {
+		return Decimal{}, ErrExponentOverflow
+	}
+	num.Lsh(num, uint(k-twos))
+	num.Mul(num, q.Exp(fiveInt, big.NewInt(k-fives), nil))
+	return newDecimal(num, int32(exp)), nil
+}
+
+// DivWithStatus returns d / d2 rounded half up to precision decimal places,
+// like DivRound, and reports whether the result is exact, i.e. whether no
+// digits other than zero were discarded by rounding. A quotient that isn't
+// finite, such as that of a division by zero or of NaN or infinite operands,
+// is never reported as exact. Neither is a division whose scale doesn't fit
+// into an int32, which returns NaN instead of panicking like DivRound, see
+// DivE.
+//
+// Example:
+//
+//	NewFromInt(10).DivWithStatus(NewFromInt(4), 2) // output: "2.5", true
+//	NewFromInt(10).DivWithStatus(NewFromInt(3), 2) // output: "3.33", false
+func (d Decimal) DivWithStatus(d2 Decimal, precision int32) (Decimal, bool) {
+	ctx := Context{Precision: precision, Rounding: RoundHalfUp}
+	q, _ := ctx.Div(d, d2)
+	return q, q.IsFinite() && ctx.Flags == 0
+}
+
 // QuoRem does division with remainder
 // d.QuoRem(d2,precision) returns quotient q and remainder r such that
 //
@@ -619,43 +910,78 @@ func (d Decimal) Div(d2 Decimal) Decimal {
 //	0 >= r > -abs(d2) * 10 ^(-precision) if d<0
 //
 // Note that precision<0 is allowed as input.
+//
+// QuoRem panics if d2 is zero. When d is an infinity or either operand is
+// NaN, both results are NaN. A finite d divided by an infinity gives a zero
+// quotient and d as remainder.
 func (d Decimal) QuoRem(d2 Decimal, precision int32) (Decimal, Decimal) {
-	d.ensureInitialized()
-	d2.ensureInitialized()
-	if d2.value.Sign() == 0 {
+	q, r, err := d.QuoRemE(d2, precision)
+	switch err {
+	case ErrDivisionByZero:
 		panic("decimal division by 0")
+	case ErrExponentOverflow:
+		panic("overflow in decimal QuoRem")
 	}
+	return q, r
+}
+
+// QuoRemE is like QuoRem, but returns ErrDivisionByZero if d2 is zero and
+// ErrExponentOverflow if the scale of the division doesn't fit into an int32,
+// instead of panicking.
+func (d Decimal) QuoRemE(d2 Decimal, precision int32) (Decimal, Decimal, error) {
+	if d.form != formFinite || d2.form != formFinite {
+		if d.form == formFinite && d2.form == formInfinite {
+			return New(0, -precision), d, nil
+		}
+		return NaN(), NaN(), nil
+	}
+	if d2.Sign() == 0 {
+		return Decimal{}, Decimal{}, ErrDivisionByZero
+	}
+	q, r, _, rexp, ok := d.quoRem(d2, precision)
+	if !ok {
+		return Decimal{}, Decimal{}, ErrExponentOverflow
+	}
+	dq := newDecimal(q, -precision)
+	dr := newDecimal(r, rexp)
+	return dq, dr, nil
+}
+
+// quoRem implements QuoRem on coefficients. Besides the coefficients of the
+// quotient q (scaled by 10^precision) and the remainder r (scaled by 10^rexp),
+// it returns the divisor bb brought to the scale of the remainder, so that
+// r/bb is the discarded fraction of the last digit of q. ok is false when the
+// scale of the division overflows an int32. d2 must be non-zero.
+func (d Decimal) quoRem(d2 Decimal, precision int32) (q, r, bb *big.Int, rexp int32, ok bool) {
 	scale := -precision
 	e := int64(d.exp) - int64(d2.exp) - int64(scale)
 	if e > math.MaxInt32 || e < math.MinInt32 {
-		panic("overflow in decimal QuoRem")
+		return nil, nil, nil, 0, false
 	}
-	var aa, bb, expo big.Int
-	var scalerest int32
+	var aa, expo big.Int
+	bb = new(big.Int)
 	// d = a 10^ea
 	// d2 = b 10^eb
 	if e < 0 {
-		aa = *d.value
+		aa = *d.coef()
 		expo.SetInt64(-e)
 		bb.Exp(tenInt, &expo, nil)
-		bb.Mul(d2.value, &bb)
-		scalerest = d.exp
+		bb.Mul(d2.coef(), bb)
+		rexp = d.exp
 		// now aa = a
 		//     bb = b 10^(scale + eb - ea)
 	} else {
 		expo.SetInt64(e)
 		aa.Exp(tenInt, &expo, nil)
-		aa.Mul(d.value, &aa)
-		bb = *d2.value
-		scalerest = scale + d2.exp
+		aa.Mul(d.coef(), &aa)
+		bb.Set(d2.coef())
+		rexp = scale + d2.exp
 		// now aa = a ^ (ea - eb - scale)
 		//     bb = b
 	}
-	var q, r big.Int
-	q.QuoRem(&aa, &bb, &r)
-	dq := Decimal{value: &q, exp: scale}
-	dr := Decimal{value: &r, exp: scalerest}
-	return dq, dr
+	q, r = new(big.Int), new(big.Int)
+	q.QuoRem(&aa, bb, r)
+	return q, r, bb, rexp, true
 }
 
 // DivRound divides and rounds to a given precision
@@ -664,45 +990,169 @@ func (d Decimal) QuoRem(d2 Decimal, precision int32) (Decimal, Decimal) {
 //	for a positive quotient digit 5 is rounded up, away from 0
 //	if the quotient is negative then digit 5 is rounded down, away from 0
 //
+// Dividing a non-zero number by zero returns an infinity, 0/0 returns NaN.
+// DivRound panics if the scale of the quotient doesn't fit into an int32, see
+// DivE.
+//
 // Note that precision<0 is allowed as input.
 func (d Decimal) DivRound(d2 Decimal, precision int32) Decimal {
-	// QuoRem already checks initialization
-	q, r := d.QuoRem(d2, precision)
-	// the actual rounding decision is based on comparing r*10^precision and d2/2
-	// instead compare 2 r 10 ^precision and d2
-	var rv2 big.Int
-	rv2.Abs(r.value)
-	rv2.Lsh(&rv2, 1)
-	// now rv2 = abs(r.value) * 2
-	r2 := Decimal{value: &rv2, exp: r.exp + precision}
-	// r2 is now 2 * r * 10 ^ precision
-	var c = r2.Cmp(d2.Abs())
-
-	if c < 0 {
-		return q
-	}
+	return d.DivRoundMode(d2, precision, RoundHalfUp)
+}
 
-	if d.value.Sign()*d2.value.Sign() < 0 {
-		return q.Sub(New(1, -precision))
+// DivRoundMode divides and rounds to a given precision using the given rounding mode,
+// i.e. to an integer multiple of 10^(-precision).
+//
+// Example:
+//
+//	NewFromInt(1).DivRoundMode(NewFromInt(8), 2, RoundHalfUp).String()   // output: "0.13"
+//	NewFromInt(1).DivRoundMode(NewFromInt(8), 2, RoundHalfEven).String() // output: "0.12"
+//	NewFromInt(-2).DivRoundMode(NewFromInt(3), 2, RoundFloor).String()   // output: "-0.67"
+//	NewFromInt(-2).DivRoundMode(NewFromInt(3), 2, RoundDown).String()    // output: "-0.66"
+//
+// Dividing a non-zero number by zero returns an infinity, 0/0 returns NaN.
+// DivRoundMode panics if the scale of the quotient doesn't fit into an int32,
+// see DivE.
+//
+// Note that precision<0 is allowed as input.
+func (d Decimal) DivRoundMode(d2 Decimal, precision int32, mode RoundingMode) Decimal {
+	ctx := Context{Precision: precision, Rounding: mode, Traps: Overflow}
+	q, err := ctx.Div(d, d2)
+	if err != nil {
+		panic(err.Error())
 	}
-
-	return q.Add(New(1, -precision))
+	return q
 }
 
 // Mod returns d % d2.
+// The remainder has the sign of d, see ModFloor and ModEuclid for the other
+// conventions. It panics if d2 is zero, see QuoRem.
 func (d Decimal) Mod(d2 Decimal) Decimal {
 	_, r := d.QuoRem(d2, 0)
 	return r
 }
 
+// ModE is like Mod, but returns ErrDivisionByZero if d2 is zero instead of
+// panicking, see QuoRemE.
+func (d Decimal) ModE(d2 Decimal) (Decimal, error) {
+	_, r, err := d.QuoRemE(d2, 0)
+	return r, err
+}
+
+// DivFloor returns the integer quotient of d / d2 rounded toward negative
+// infinity, the quotient of ModFloor. Contrary to QuoRem, which truncates
+// toward zero, DivFloor(-7, 2) is -4.
+//
+// DivFloor is exact for any scale of d and d2. It panics if d2 is zero, and
+// handles NaN and infinities like QuoRem, extended by the rounding of the
+// quotient.
+//
+// Example:
+//
+//	NewFromInt(-7).DivFloor(NewFromInt(2)).String()      // output: "-4"
+//	NewFromFloat(7.5).DivFloor(NewFromFloat(-2)).String() // output: "-4"
+func (d Decimal) DivFloor(d2 Decimal) Decimal {
+	q, _ := d.quoRemFloor(d2, false)
+	return q
+}
+
+// ModFloor returns the remainder d - d2 * DivFloor(d, d2), which has the sign
+// of d2, as in Python, rather than the sign of d as Mod does.
+//
+// Example:
+//
+//	NewFromInt(-7).ModFloor(NewFromInt(2)).String()       // output: "1"
+//	NewFromFloat(7.5).ModFloor(NewFromFloat(-2)).String() // output: "-0.5"
+func (d Decimal) ModFloor(d2 Decimal) Decimal {
+	_, r := d.quoRemFloor(d2, false)
+	return r
+}
+
+// DivEuclid returns the integer quotient of the Euclidean division of d by d2,
+// the quotient of ModEuclid. It is rounded toward negative infinity for a
+// positive d2 and toward positive infinity for a negative one.
+//
+// DivEuclid is exact for any scale of d and d2. It panics if d2 is zero, and
+// handles NaN and infinities like QuoRem, extended by the rounding of the
+// quotient.
+//
+// Example:
+//
+//	NewFromInt(-7).DivEuclid(NewFromInt(2)).String()  // output: "-4"
+//	NewFromInt(-7).DivEuclid(NewFromInt(-2)).String() // output: "4"
+func (d Decimal) DivEuclid(d2 Decimal) Decimal {
+	q, _ := d.quoRemFloor(d2, true)
+	return q
+}
+
+// ModEuclid returns the remainder d - d2 * DivEuclid(d, d2), which is never
+// negative: 0 <= r < abs(d2).
+//
+// Example:
+//
+//	NewFromInt(-7).ModEuclid(NewFromInt(2)).String()  // output: "1"
+//	NewFromInt(-7).ModEuclid(NewFromInt(-2)).String() // output: "1"
+func (d Decimal) ModEuclid(d2 Decimal) Decimal {
+	_, r := d.quoRemFloor(d2, true)
+	return r
+}
+
+// quoRemFloor adjusts the integer quotient and remainder of QuoRem, so that
+// the remainder has the sign of d2, or isn't negative if euclid is set.
+func (d Decimal) quoRemFloor(d2 Decimal, euclid bool) (Decimal, Decimal) {
+	q, r := d.QuoRem(d2, 0)
+	if r.IsNaN() || r.Sign() == 0 {
+		return q, r
+	}
+	switch {
+	case euclid && r.Sign() < 0 && d2.Sign() < 0:
+		return q.Add(New(1, 0)), r.Sub(d2)
+	case euclid && r.Sign() < 0, !euclid && r.Sign() != d2.Sign():
+		return q.Sub(New(1, 0)), r.Add(d2)
+	}
+	return q, r
+}
+
+// Rem returns the IEEE 754 remainder of d / d2, d - d2 * n, where n is the
+// quotient d / d2 rounded to the nearest integer, half to even, as
+// math.Remainder does. The result is in [-abs(d2)/2, abs(d2)/2].
+//
+// Rem is exact for any scale of d and d2. It panics if d2 is zero, and returns
+// NaN if d is an infinity or either operand is NaN, like QuoRem. A finite d
+// divided by an infinity gives d.
+//
+// Example:
+//
+//	NewFromInt(5).Rem(NewFromInt(3)).String()     // output: "-1"
+//	NewFromFloat(2.5).Rem(NewFromInt(1)).String() // output: "0.5"
+func (d Decimal) Rem(d2 Decimal) Decimal {
+	q, r := d.QuoRem(d2, 0)
+	if r.form != formFinite || r.Sign() == 0 {
+		return r
+	}
+
+	// round the truncated quotient away from zero, by s = sign(d / d2), if
+	// the remainder exceeds half of d2, or equals it and q is odd
+	twice := r.Add(r).Abs()
+	c := twice.Cmp(d2.Abs())
+	if c > 0 || (c == 0 && q.coef().Bit(0) == 1) {
+		if r.Sign() == d2.Sign() {
+			return r.Sub(d2)
+		}
+		return r.Add(d2)
+	}
+	return r
+}
+
 // Pow returns d to the power of d2.
 // When exponent is negative the returned decimal will have maximum precision of PowPrecisionNegativeExponent places after decimal point.
 //
-// Pow returns 0 (zero-value of Decimal) instead of error for power operation edge cases, to handle those edge cases use PowWithPrecision
+// Pow returns NaN or an infinity instead of error for power operation edge cases, to handle those edge cases use PowWithPrecision
 // Edge cases not handled by Pow:
-//   - 0 ** 0 => undefined value
-//   - 0 ** y, where y < 0 => infinity
-//   - x ** y, where x < 0 and y is non-integer decimal => imaginary value
+//   - 0 ** 0 => NaN (undefined value)
+//   - 0 ** y, where y < 0 => Inf
+//   - x ** y, where x < 0 and y is non-integer decimal => NaN (imaginary value)
+//
+// Powers of infinities and powers with an infinite exponent follow the IEEE 754 rules.
 //
 // Example:
 //
@@ -716,37 +1166,41 @@ func (d Decimal) Mod(d2 Decimal) Decimal {
 //	res2 := d3.Pow(d4)
 //	res2.String() // output: "10118.08037125"
 func (d Decimal) Pow(d2 Decimal) Decimal {
+	if d.form != formFinite || d2.form != formFinite {
+		return powSpecial(d, d2)
+	}
+
 	baseSign := d.Sign()
 	expSign := d2.Sign()
 
 	if baseSign == 0 {
 		if expSign == 0 {
-			return Decimal{}
+			return NaN()
 		}
 		if expSign == 1 {
-			return Decimal{zeroInt, 0}
+			return New(0, 0)
 		}
 		if expSign == -1 {
-			return Decimal{}
+			return Inf(1)
 		}
 	}
 
 	if expSign == 0 {
-		return Decimal{oneInt, 0}
+		return New(1, 0)
 	}
 
 	// TODO: optimize extraction of fractional part
-	one := Decimal{oneInt, 0}
+	one := New(1, 0)
 	expIntPart, expFracPart := d2.QuoRem(one, 0)
 
 	if baseSign == -1 && !expFracPart.IsZero() {
-		return Decimal{}
+		return NaN()
 	}
 
-	intPartPow, _ := d.PowBigInt(expIntPart.value)
+	intPartPow, _ := d.PowBigInt(expIntPart.coef())
 
 	// if exponent is an integer we don't need to calculate d1**frac(d2)
-	if expFracPart.value.Sign() == 0 {
+	if expFracPart.Sign() == 0 {
 		return intPartPow
 	}
 
@@ -783,6 +1237,36 @@ func (d Decimal) Pow(d2 Decimal) Decimal {
 	return res
 }
 
+// powSpecial returns d**d2 when d or d2 isn't finite.
+func powSpecial(d, d2 Decimal) Decimal {
+	if d.IsNaN() || d2.IsNaN() {
+		return NaN()
+	}
+	if d2.IsZero() {
+		return New(1, 0)
+	}
+
+	if d2.form == formInfinite {
+		// the base decides between 0 and Inf by its magnitude
+		c := d.Abs().Cmp(New(1, 0))
+		switch {
+		case c == 0:
+			return New(1, 0)
+		case (c > 0) != d2.neg:
+			return Inf(1)
+		default:
+			return Decimal{}
+		}
+	}
+
+	// only odd integer exponents keep the sign of -Inf
+	neg := d.neg && d2.IsInteger() && d2.Mod(New(2, 0)).Sign() != 0
+	if d2.Sign() > 0 {
+		return Decimal{form: formInfinite, neg: neg}
+	}
+	return Decimal{neg: neg}
+}
+
 // PowWithPrecision returns d to the power of d2.
 // Precision parameter specifies minimum precision of the result (digits after decimal point).
 // Returned decimal is not rounded to 'precision' places after decimal point.
@@ -809,76 +1293,13 @@ func (d Decimal) Pow(d2 Decimal) Decimal {
 //	res3, err := d5.PowWithPrecision(d6, 10)
 //	res3.String() // output: "0.0013717421"
 func (d Decimal) PowWithPrecision(d2 Decimal, precision int32) (Decimal, error) {
-	baseSign := d.Sign()
-	expSign := d2.Sign()
-
-	if baseSign == 0 {
-		if expSign == 0 {
-			return Decimal{}, fmt.Errorf("cannot represent undefined value of 0**0")
-		}
-		if expSign == 1 {
-			return Decimal{zeroInt, 0}, nil
-		}
-		if expSign == -1 {
-			return Decimal{}, fmt.Errorf("cannot represent infinity value of 0 ** y, where y < 0")
-		}
-	}
-
-	if expSign == 0 {
-		return Decimal{oneInt, 0}, nil
-	}
-
-	// TODO: optimize extraction of fractional part
-	one := Decimal{oneInt, 0}
-	expIntPart, expFracPart := d2.QuoRem(one, 0)
-
-	if baseSign == -1 && !expFracPart.IsZero() {
-		return Decimal{}, fmt.Errorf("cannot represent imaginary value of x ** y, where x < 0 and y is non-integer decimal")
-	}
-
-	intPartPow, _ := d.powBigIntWithPrecision(expIntPart.value, precision)
-
-	// if exponent is an integer we don't need to calculate d1**frac(d2)
-	if expFracPart.value.Sign() == 0 {
-		return intPartPow, nil
-	}
-
-	// TODO: optimize NumDigits for more performant precision adjustment
-	digitsBase := d.NumDigits()
-	digitsExponent := d2.NumDigits()
-
-	if int32(digitsBase) > precision {
-		precision = int32(digitsBase)
-	}
-	if int32(digitsExponent) > precision {
-		precision += int32(digitsExponent)
-	}
-	// increase precision by 10 to compensate for errors in further calculations
-	precision += 10
-
-	// Calculate x ** frac(y), where
-	// x ** frac(y) = exp(ln(x ** frac(y)) = exp(ln(x) * frac(y))
-	fracPartPow, err := d.Abs().Ln(precision)
-	if err != nil {
-		return Decimal{}, err
-	}
-
-	fracPartPow = fracPartPow.Mul(expFracPart)
-
-	fracPartPow, err = fracPartPow.ExpTaylor(precision)
-	if err != nil {
-		return Decimal{}, err
-	}
-
-	// Join integer and fractional part,
-	// base ** (expBase + expFrac) = base ** expBase * base ** expFrac
-	res := intPartPow.Mul(fracPartPow)
-
-	return res, nil
+	ctx := Context{Precision: precision, Traps: DivisionByZero | InvalidOperation}
+	return ctx.pow(d, d2, precision)
 }
 
 // PowInt32 returns d to the power of exp, where exp is int32.
 // Only returns error when d and exp is 0, thus result is undefined.
+// A zero raised to a negative exp returns Inf.
 //
 // When exponent is negative the returned decimal will have maximum precision of PowPrecisionNegativeExponent places after decimal point.
 //
@@ -890,6 +1311,9 @@ func (d Decimal) PowWithPrecision(d2 Decimal, precision int32) (Decimal, error)
 //	d2, err := decimal.NewFromFloat(3.13).PowInt32(5)
 //	d2.String() // output: "300.4150512793"
 func (d Decimal) PowInt32(exp int32) (Decimal, error) {
+	if d.form != formFinite {
+		return powSpecial(d, NewFromInt32(exp)), nil
+	}
 	if d.IsZero() && exp == 0 {
 		return Decimal{}, fmt.Errorf("cannot represent undefined value of 0**0")
 	}
@@ -919,6 +1343,7 @@ func (d Decimal) PowInt32(exp int32) (Decimal, error) {
 
 // PowBigInt returns d to the power of exp, where exp is big.Int.
 // Only returns error when d and exp is 0, thus result is undefined.
+// A zero raised to a negative exp returns Inf.
 //
 // When exponent is negative the returned decimal will have maximum precision of PowPrecisionNegativeExponent places after decimal point.
 //
@@ -934,6 +1359,9 @@ func (d Decimal) PowBigInt(exp *big.Int) (Decimal, error) {
 }
 
 func (d Decimal) powBigIntWithPrecision(exp *big.Int, precision int32) (Decimal, error) {
+	if d.form != formFinite {
+		return powSpecial(d, NewFromBigInt(exp, 0)), nil
+	}
 	if d.IsZero() && exp.Sign() == 0 {
 		return Decimal{}, fmt.Errorf("cannot represent undefined value of 0**0")
 	}
@@ -977,8 +1405,11 @@ func (d Decimal) powBigIntWithPrecision(exp *big.Int, precision int32) (Decimal,
 func (d Decimal) ExpHullAbrham(overallPrecision uint32) (Decimal, error) {
 	// Algorithm based on Variable precision exponential function.
 	// ACM Transactions on Mathematical Software by T. E. Hull & A. Abrham.
+	if d.form != formFinite {
+		return expSpecial(d), nil
+	}
 	if d.IsZero() {
-		return Decimal{oneInt, 0}, nil
+		return New(1, 0), nil
 	}
 
 	currentPrecision := overallPrecision
@@ -1000,7 +1431,7 @@ func (d Decimal) ExpHullAbrham(overallPrecision uint32) (Decimal, error) {
 	// Return 1 if abs(d) small enough; this also avoids later over/underflow
 	overflowThreshold2 := New(9, -int32(currentPrecision)-1)
 	if d.Abs().Cmp(overflowThreshold2) <= 0 {
-		return Decimal{oneInt, d.exp}, nil
+		return New(1, d.exp), nil
 	}
 
 	// t is the smallest integer >= 0 such that the corresponding abs(d/k) < 1
@@ -1010,9 +1441,9 @@ func (d Decimal) ExpHullAbrham(overallPrecision uint32) (Decimal, error) {
 		t = 0
 	}
 
-	k := New(1, t)                                     // reduction factor
-	r := Decimal{new(big.Int).Set(d.value), d.exp - t} // reduced argument
-	p := int32(currentPrecision) + t + 2               // precision for calculating the sum
+	k := New(1, t)                       // reduction factor
+	r := d.Shift(-t)                     // reduced argument
+	p := int32(currentPrecision) + t + 2 // precision for calculating the sum
 
 	// Determine n, the number of therms for calculating sum
 	// use first Newton step (1.435p - 1.182) / log10(p/abs(r))
@@ -1026,12 +1457,10 @@ func (d Decimal) ExpHullAbrham(overallPrecision uint32) (Decimal, error) {
 	}
 	n := int64(nf)
 
-	tmp := New(0, 0)
 	sum := New(1, 0)
 	one := New(1, 0)
 	for i := n - 1; i > 0; i-- {
-		tmp.value.SetInt64(i)
-		sum = sum.Mul(r.DivRound(tmp, p))
+		sum = sum.Mul(r.DivRound(New(i, 0), p))
 		sum = sum.Add(one)
 	}
 
@@ -1073,8 +1502,11 @@ func (d Decimal) ExpHullAbrham(overallPrecision uint32) (Decimal, error) {
 //	d.String()  // output: "220000000000"
 func (d Decimal) ExpTaylor(precision int32) (Decimal, error) {
 	// Note(mwoss): Implementation can be optimized by exclusively using big.Int API only
+	if d.form != formFinite {
+		return expSpecial(d), nil
+	}
 	if d.IsZero() {
-		return Decimal{oneInt, 0}.Round(precision), nil
+		return New(1, 0).Round(precision), nil
 	}
 
 	var epsilon Decimal
@@ -1127,6 +1559,17 @@ func (d Decimal) ExpTaylor(precision int32) (Decimal, error) {
 	return result, nil
 }
 
+// expSpecial returns e**d when d isn't finite.
+func expSpecial(d Decimal) Decimal {
+	switch {
+	case d.IsNaN():
+		return NaN()
+	case d.neg:
+		return Decimal{}
+	}
+	return d
+}
+
 // Ln calculates natural logarithm of d.
 // Precision argument specifies how precise the result must be (number of digits after decimal point).
 // Negative precision is allowed.
@@ -1139,8 +1582,12 @@ func (d Decimal) ExpTaylor(precision int32) (Decimal, error) {
 //	d2, err := NewFromFloat(579.161).Ln(10)
 //	d2.String()  // output: "6.3615805046"
 func (d Decimal) Ln(precision int32) (Decimal, error) {
-	// Algorithm based on The Use of Iteration Methods for Approximating the Natural Logarithm,
-	// James F. Epperson, The American Mathematical Monthly, Vol. 96, No. 9, November 1989, pp. 831-835.
+	if d.IsNaN() {
+		return NaN(), nil
+	}
+	if d.IsInf(1) {
+		return d, nil
+	}
 	if d.IsNegative() {
 		return Decimal{}, fmt.Errorf("cannot calculate natural logarithm for negative decimals")
 	}
@@ -1153,15 +1600,12 @@ func (d Decimal) Ln(precision int32) (Decimal, error) {
 	z := d.Copy()
 
 	var comp1, comp3, comp2, comp4, reduceAdjust Decimal
-	comp1 = z.Sub(Decimal{oneInt, 0})
-	comp3 = Decimal{oneInt, -1}
+	comp1 = z.Sub(New(1, 0))
+	comp3 = New(1, -1)
 
-	// for decimal in range [0.9, 1.1] where ln(d) is close to 0
-	usePowerSeries := false
-
-	if comp1.Abs().Cmp(comp3) <= 0 {
-		usePowerSeries = true
-	} else {
+	// for decimal in range [0.9, 1.1] where ln(d) is close to 0 the power
+	// series converges quickly, other decimals are reduced to range [0.7, 1.4]
+	if comp1.Abs().Cmp(comp3) > 0 {
 		// reduce input decimal to range [0.1, 1)
 		expDelta := int32(z.NumDigits()) + z.exp
 		z.exp -= expDelta
@@ -1173,80 +1617,43 @@ func (d Decimal) Ln(precision int32) (Decimal, error) {
 		reduceAdjust = NewFromInt32(expDelta)
 		reduceAdjust = reduceAdjust.Mul(ln10)
 
-		comp1 = z.Sub(Decimal{oneInt, 0})
+		// multiply by 2^k for k in [0, 3] to reach range [0.7, 1.4], and
+		// subtract k * ln(2) from the result
+		k := int64(math.Floor(0.5 - math.Log2(z.InexactFloat64())))
+		z = z.Mul(New(1<<uint(k), 0))
+		reduceAdjust = reduceAdjust.Sub(New(k, 0).Mul(ln2.withPrecision(calcPrecision)))
 
-		if comp1.Abs().Cmp(comp3) <= 0 {
-			usePowerSeries = true
-		} else {
-			// initial estimate using floats
-			zFloat := z.InexactFloat64()
-			comp1 = NewFromFloat(math.Log(zFloat))
-		}
+		comp1 = z.Sub(New(1, 0))
 	}
 
-	epsilon := Decimal{oneInt, -calcPrecision}
+	epsilon := New(1, -calcPrecision)
 
-	if usePowerSeries {
-		// Power Series - https://en.wikipedia.org/wiki/Logarithm#Power_series
-		// Calculating n-th term of formula: ln(z+1) = 2 sum [ 1 / (2n+1) * (z / (z+2))^(2n+1) ]
-		// until the difference between current and next term is smaller than epsilon.
-		// Coverage quite fast for decimals close to 1.0
+	// Power Series - https://en.wikipedia.org/wiki/Logarithm#Power_series
+	// Calculating n-th term of formula: ln(z+1) = 2 sum [ 1 / (2n+1) * (z / (z+2))^(2n+1) ]
+	// until the difference between current and next term is smaller than epsilon.
+	// Coverage quite fast for decimals close to 1.0
 
-		// z + 2
-		comp2 = comp1.Add(Decimal{twoInt, 0})
-		// z / (z + 2)
-		comp3 = comp1.DivRound(comp2, calcPrecision)
-		// 2 * (z / (z + 2))
-		comp1 = comp3.Add(comp3)
-		comp2 = comp1.Copy()
+	// z + 2
+	comp2 = comp1.Add(New(2, 0))
+	// z / (z + 2)
+	comp3 = comp1.DivRound(comp2, calcPrecision)
+	// 2 * (z / (z + 2))
+	comp1 = comp3.Add(comp3)
+	comp2 = comp1.Copy()
 
-		for n := 1; ; n++ {
-			// 2 * (z / (z+2))^(2n+1)
-			comp2 = comp2.Mul(comp3).Mul(comp3)
+	for n := 1; ; n++ {
+		// 2 * (z / (z+2))^(2n+1)
+		comp2 = comp2.Mul(comp3).Mul(comp3)
 
-			// 1 / (2n+1) * 2 * (z / (z+2))^(2n+1)
-			comp4 = NewFromInt(int64(2*n + 1))
-			comp4 = comp2.DivRound(comp4, calcPrecision)
+		// 1 / (2n+1) * 2 * (z / (z+2))^(2n+1)
+		comp4 = NewFromInt(int64(2*n + 1))
+		comp4 = comp2.DivRound(comp4, calcPrecision)
 
-			// comp1 = 2 sum [ 1 / (2n+1) * (z / (z+2))^(2n+1) ]
-			comp1 = comp1.Add(comp4)
+		// comp1 = 2 sum [ 1 / (2n+1) * (z / (z+2))^(2n+1) ]
+		comp1 = comp1.Add(comp4)
 
-			if comp4.Abs().Cmp(epsilon) <= 0 {
-				break
-			}
-		}
-	} else {
-		// Halley's Iteration.
-		// Calculating n-th term of formula: a_(n+1) = a_n - 2 * (exp(a_n) - z) / (exp(a_n) + z),
-		// until the difference between current and next term is smaller than epsilon
-		var prevStep Decimal
-		maxIters := calcPrecision*2 + 10
-
-		for i := int32(0); i < maxIters; i++ {
-			// exp(a_n)
-			comp3, _ = comp1.ExpTaylor(calcPrecision)
-			// exp(a_n) - z
-			comp2 = comp3.Sub(z)
-			// 2 * (exp(a_n) - z)
-			comp2 = comp2.Add(comp2)
-			// exp(a_n) + z
-			comp4 = comp3.Add(z)
-			// 2 * (exp(a_n) - z) / (exp(a_n) + z)
-			comp3 = comp2.DivRound(comp4, calcPrecision)
-			// comp1 = a_(n+1) = a_n - 2 * (exp(a_n) - z) / (exp(a_n) + z)
-			comp1 = comp1.Sub(comp3)
-
-			if prevStep.Add(comp3).IsZero() {
-				// If iteration steps oscillate we should return early and prevent an infinity loop
-				// NOTE(mwoss): This should be quite a rare case, returning error is not necessary
-				break
-			}
-
-			if comp3.Abs().Cmp(epsilon) <= 0 {
-				break
-			}
-
-			prevStep = comp3
+		if comp4.Abs().Cmp(epsilon) <= 0 {
+			break
 		}
 	}
 
@@ -1258,7 +1665,7 @@ func (d Decimal) Ln(precision int32) (Decimal, error) {
 // NumDigits returns the number of digits of the decimal coefficient (d.Value)
 func (d Decimal) NumDigits() int {
 	if d.value == nil {
-		return 1
+		return numDigits64(d.compact)
 	}
 
 	if d.value.IsInt64() {
@@ -1286,11 +1693,21 @@ func (d Decimal) NumDigits() int {
 }
 
 // IsInteger returns true when decimal can be represented as an integer value, otherwise, it returns false.
+// NaN and infinities aren't integers.
 func (d Decimal) IsInteger() bool {
+	if d.form != formFinite {
+		return false
+	}
 	// The most typical case, all decimal with exponent higher or equal 0 can be represented as integer
 	if d.exp >= 0 {
 		return true
 	}
+	if d.value == nil {
+		if -int64(d.exp) > maxPow10Int64 {
+			return d.compact == 0
+		}
+		return d.compact%int64(pow10Uint64[-d.exp]) == 0
+	}
 	// When the exponent is negative we have to check every number after the decimal place
 	// If all of them are zeroes, we are sure that given decimal can be represented as an integer
 	var r big.Int
@@ -1317,17 +1734,59 @@ func abs(n int32) int32 {
 //	-1 if d <  d2
 //	 0 if d == d2
 //	+1 if d >  d2
+//
+// Like cmp.Compare for floats, Cmp orders NaN before -Inf and treats any two
+// NaNs as equal, so that it is a total order. A negative zero is equal to zero.
 func (d Decimal) Cmp(d2 Decimal) int {
-	d.ensureInitialized()
-	d2.ensureInitialized()
+	if d.form != formFinite || d2.form != formFinite {
+		return cmpSpecial(d, d2)
+	}
+
+	if d.value == nil && d2.value == nil {
+		if a, b, _, ok := rescalePair64(d, d2); ok {
+			switch {
+			case a < b:
+				return -1
+			case a > b:
+				return 1
+			}
+			return 0
+		}
+	}
 
 	if d.exp == d2.exp {
-		return d.value.Cmp(d2.value)
+		return d.coef().Cmp(d2.coef())
 	}
 
 	rd, rd2 := RescalePair(d, d2)
 
-	return rd.value.Cmp(rd2.value)
+	return rd.coef().Cmp(rd2.coef())
+}
+
+// cmpSpecial compares d and d2 when at least one of them isn't finite.
+func cmpSpecial(d, d2 Decimal) int {
+	r, r2 := d.cmpRank(), d2.cmpRank()
+	switch {
+	case r < r2:
+		return -1
+	case r > r2:
+		return 1
+	}
+	return 0
+}
+
+// cmpRank orders the kinds of numbers: NaN, -Inf, finite, +Inf.
+func (d Decimal) cmpRank() int {
+	switch d.form {
+	case formFinite:
+		return 0
+	case formInfinite:
+		if d.neg {
+			return -1
+		}
+		return 1
+	}
+	return -2
 }
 
 // Compare compares the numbers represented by d and d2 and returns:
@@ -1335,13 +1794,21 @@ func (d Decimal) Cmp(d2 Decimal) int {
 //	-1 if d <  d2
 //	 0 if d == d2
 //	+1 if d >  d2
+//
+// NaNs are ordered like by Cmp.
 func (d Decimal) Compare(d2 Decimal) int {
 	return d.Cmp(d2)
 }
 
 // Equal returns whether the numbers represented by d and d2 are equal.
+// Like all comparisons below, it returns false when d or d2 is NaN.
 func (d Decimal) Equal(d2 Decimal) bool {
-	return d.Cmp(d2) == 0
+	return !unordered(d, d2) && d.Cmp(d2) == 0
+}
+
+// unordered reports whether d or d2 is NaN, which makes all comparisons false.
+func unordered(d, d2 Decimal) bool {
+	return d.IsNaN() || d2.IsNaN()
 }
 
 // Deprecated: Equals is deprecated, please use Equal method instead.
@@ -1351,22 +1818,28 @@ func (d Decimal) Equals(d2 Decimal) bool {
 
 // GreaterThan (GT) returns true when d is greater than d2.
 func (d Decimal) GreaterThan(d2 Decimal) bool {
-	return d.Cmp(d2) == 1
+	return !unordered(d, d2) && d.Cmp(d2) == 1
 }
 
 // GreaterThanOrEqual (GTE) returns true when d is greater than or equal to d2.
 func (d Decimal) GreaterThanOrEqual(d2 Decimal) bool {
+	if unordered(d, d2) {
+		return false
+	}
 	cmp := d.Cmp(d2)
 	return cmp == 1 || cmp == 0
 }
 
 // LessThan (LT) returns true when d is less than d2.
 func (d Decimal) LessThan(d2 Decimal) bool {
-	return d.Cmp(d2) == -1
+	return !unordered(d, d2) && d.Cmp(d2) == -1
 }
 
 // LessThanOrEqual (LTE) returns true when d is less than or equal to d2.
 func (d Decimal) LessThanOrEqual(d2 Decimal) bool {
+	if unordered(d, d2) {
+		return false
+	}
 	cmp := d.Cmp(d2)
 	return cmp == -1 || cmp == 0
 }
@@ -1376,8 +1849,22 @@ func (d Decimal) LessThanOrEqual(d2 Decimal) bool {
 //	-1 if d <  0
 //	 0 if d == 0
 //	+1 if d >  0
+//
+// The sign of an infinity is ±1, the sign of NaN is 0.
 func (d Decimal) Sign() int {
+	if d.form == formInfinite {
+		if d.neg {
+			return -1
+		}
+		return 1
+	}
 	if d.value == nil {
+		switch {
+		case d.compact < 0:
+			return -1
+		case d.compact > 0:
+			return 1
+		}
 		return 0
 	}
 	return d.value.Sign()
@@ -1407,7 +1894,31 @@ func (d Decimal) IsNegative() bool {
 //	false if d > 0
 //	false if d < 0
 func (d Decimal) IsZero() bool {
-	return d.Sign() == 0
+	return d.form == formFinite && d.Sign() == 0
+}
+
+// IsNaN reports whether d is a quiet or signaling NaN.
+func (d Decimal) IsNaN() bool {
+	return d.form == formNaN || d.form == formSNaN
+}
+
+// IsInf reports whether d is an infinity, according to sign.
+// If sign > 0, IsInf reports whether d is positive infinity.
+// If sign < 0, IsInf reports whether d is negative infinity.
+// If sign == 0, IsInf reports whether d is either infinity.
+func (d Decimal) IsInf(sign int) bool {
+	return d.form == formInfinite && (sign == 0 || (sign < 0) == d.neg)
+}
+
+// IsFinite reports whether d is neither NaN nor an infinity.
+func (d Decimal) IsFinite() bool {
+	return d.form == formFinite
+}
+
+// Signbit reports whether d is negative or a negative zero.
+// It is false for NaN.
+func (d Decimal) Signbit() bool {
+	return d.neg || (d.form == formFinite && d.Sign() < 0)
 }
 
 // Exponent returns the exponent, or scale component of the decimal.
@@ -1417,7 +1928,9 @@ func (d Decimal) Exponent() int32 {
 
 // Coefficient returns the coefficient of the decimal. It is scaled by 10^Exponent()
 func (d Decimal) Coefficient() *big.Int {
-	d.ensureInitialized()
+	if d.value == nil {
+		return big.NewInt(d.compact)
+	}
 	// we copy the coefficient so that mutating the result does not mutate the Decimal.
 	return new(big.Int).Set(d.value)
 }
@@ -1425,48 +1938,84 @@ func (d Decimal) Coefficient() *big.Int {
 // CoefficientInt64 returns the coefficient of the decimal as int64. It is scaled by 10^Exponent()
 // If coefficient cannot be represented in an int64, the result will be undefined.
 func (d Decimal) CoefficientInt64() int64 {
-	d.ensureInitialized()
+	if d.value == nil {
+		return d.compact
+	}
 	return d.value.Int64()
 }
 
 // IntPart returns the integer component of the decimal.
+// It returns 0 for NaN and infinities, which have no integer component, so use
+// IsFinite to tell them apart from decimals with a zero integer part.
 func (d Decimal) IntPart() int64 {
+	if d.form != formFinite {
+		return 0
+	}
 	scaledD := d.rescale(0)
+	if scaledD.value == nil {
+		return scaledD.compact
+	}
 	return scaledD.value.Int64()
 }
 
 // BigInt returns integer component of the decimal as a BigInt.
+// It returns 0 for NaN and infinities, which have no integer component, so use
+// IsFinite to tell them apart from decimals with a zero integer part.
 func (d Decimal) BigInt() *big.Int {
+	if d.form != formFinite {
+		return new(big.Int)
+	}
 	scaledD := d.rescale(0)
-	return scaledD.value
+	return scaledD.Coefficient()
 }
 
 // BigFloat returns decimal as BigFloat.
 // Be aware that casting decimal to BigFloat might cause a loss of precision.
+// Infinities map to big.Float infinities. BigFloat panics for NaN, which
+// big.Float can't represent.
 func (d Decimal) BigFloat() *big.Float {
+	if d.form != formFinite {
+		if d.IsNaN() {
+			panic("Cannot create a big.Float from NaN")
+		}
+		return new(big.Float).SetInf(d.neg)
+	}
 	f := &big.Float{}
 	f.SetString(d.String())
 	return f
 }
 
 // Rat returns a rational number representation of the decimal.
+// It panics for NaN and infinities.
 func (d Decimal) Rat() *big.Rat {
-	d.ensureInitialized()
+	if d.form != formFinite {
+		panic(fmt.Sprintf("Cannot create a big.Rat from %s", d.String()))
+	}
 	if d.exp <= 0 {
 		// NOTE(vadim): must negate after casting to prevent int32 overflow
 		denom := new(big.Int).Exp(tenInt, big.NewInt(-int64(d.exp)), nil)
-		return new(big.Rat).SetFrac(d.value, denom)
+		return new(big.Rat).SetFrac(d.coef(), denom)
 	}
 
 	mul := new(big.Int).Exp(tenInt, big.NewInt(int64(d.exp)), nil)
-	num := new(big.Int).Mul(d.value, mul)
+	num := new(big.Int).Mul(d.coef(), mul)
 	return new(big.Rat).SetFrac(num, oneInt)
 }
 
 // Float64 returns the nearest float64 value for d and a bool indicating
 // whether f represents d exactly.
 // For more details, see the documentation for big.Rat.Float64
+//
+// NaN, infinities and a negative zero convert exactly to their float64 counterparts.
 func (d Decimal) Float64() (f float64, exact bool) {
+	switch {
+	case d.IsNaN():
+		return math.NaN(), true
+	case d.form == formInfinite:
+		return math.Inf(d.Sign()), true
+	case d.neg:
+		return math.Copysign(0, -1), true
+	}
 	return d.Rat().Float64()
 }
 
@@ -1488,6 +2037,8 @@ func (d Decimal) InexactFloat64() float64 {
 // Output:
 //
 //	-12.345
+//
+// A negative zero is printed without sign like any zero, see Signbit.
 func (d Decimal) String() string {
 	return d.string(TrimTrailingZeros, AvoidScientificNotation)
 }
@@ -1507,8 +2058,7 @@ func (d Decimal) String() string {
 //
 // Regardless of the `AvoidScientificNotation` option, the returned string will never be in scientific notation.
 func (d Decimal) StringFixed(places int32) string {
-	rounded := d.Round(places)
-	return rounded.string(false, true)
+	return d.StringFixedMode(places, RoundHalfUp)
 }
 
 // StringFixedBank returns a banker rounded fixed-point string with places digits
@@ -1526,7 +2076,39 @@ func (d Decimal) StringFixed(places int32) string {
 //
 // Regardless of the `AvoidScientificNotation` option, the returned string will never be in scientific notation.
 func (d Decimal) StringFixedBank(places int32) string {
-	rounded := d.RoundBank(places)
+	return d.StringFixedMode(places, RoundHalfEven)
+}
+
+// StringFixedMode returns a fixed-point string with places digits after the
+// decimal point, rounded using the given rounding mode.
+//
+// Example:
+//
+//	NewFromFloat(5.45).StringFixedMode(1, RoundHalfUp)   // output: "5.5"
+//	NewFromFloat(5.45).StringFixedMode(1, RoundHalfEven) // output: "5.4"
+//	NewFromFloat(5.45).StringFixedMode(1, RoundDown)     // output: "5.4"
+//	NewFromFloat(5.41).StringFixedMode(1, RoundCeiling)  // output: "5.5"
+//	NewFromFloat(5.45).StringFixedMode(3, RoundFloor)    // output: "5.450"
+//
+// Regardless of the `AvoidScientificNotation` option, the returned string will never be in scientific notation.
+func (d Decimal) StringFixedMode(places int32, mode RoundingMode) string {
+	rounded := d.RoundMode(places, mode)
+	return rounded.string(false, true)
+}
+
+// StringSignificant returns a string with n significant digits, rounded half up.
+// Trailing zeros are kept, see RoundSignificant.
+//
+// Example:
+//
+//	NewFromFloat(0.000123456).StringSignificant(3) // output: "0.000123"
+//	NewFromInt(123456000).StringSignificant(3)     // output: "123000000"
+//	NewFromFloat(1.5).StringSignificant(3)         // output: "1.50"
+//	NewFromInt(0).StringSignificant(3)             // output: "0.00"
+//
+// Regardless of the `AvoidScientificNotation` option, the returned string will never be in scientific notation.
+func (d Decimal) StringSignificant(n int) string {
+	rounded := d.RoundSignificant(n, RoundHalfUp)
 	return rounded.string(false, true)
 }
 
@@ -1547,27 +2129,113 @@ func (d Decimal) StringFixedCash(interval uint8) string {
 //	NewFromFloat(5.45).Round(1).String() // output: "5.5"
 //	NewFromFloat(545).Round(-1).String() // output: "550" (with AvoidScientificNotation, "5.5E2" otherwise)
 func (d Decimal) Round(places int32) Decimal {
+	return d.RoundMode(places, RoundHalfUp)
+}
+
+// RoundMode rounds the decimal to places decimal places using the given rounding mode.
+// If places < 0, it will round the integer part to a multiple of 10^(-places).
+// The result always has exactly places decimal places, as the result of Round.
+//
+// Example:
+//
+//	NewFromFloat(5.45).RoundMode(1, RoundHalfUp).String()    // output: "5.5"
+//	NewFromFloat(5.45).RoundMode(1, RoundHalfDown).String()  // output: "5.4"
+//	NewFromFloat(5.45).RoundMode(1, RoundHalfEven).String()  // output: "5.4"
+//	NewFromFloat(5.55).RoundMode(1, RoundHalfOdd).String()   // output: "5.5"
+//	NewFromFloat(-5.41).RoundMode(1, RoundCeiling).String()  // output: "-5.4"
+//	NewFromFloat(-5.41).RoundMode(1, RoundFloor).String()    // output: "-5.5"
+//	NewFromFloat(5.41).RoundMode(1, RoundUp).String()        // output: "5.5"
+//	NewFromFloat(5.49).RoundMode(1, RoundDown).String()      // output: "5.4"
+//	NewFromFloat(5.01).RoundMode(1, Round05Up).String()      // output: "5.1"
+//	NewFromFloat(545).RoundMode(-1, RoundHalfEven).String()  // output: "540"
+func (d Decimal) RoundMode(places int32, mode RoundingMode) Decimal {
+	if d.form == formFinite {
+		d.neg = false
+	}
 	if d.exp == -places {
 		return d
 	}
-	// truncate to places + 1
-	ret := d.rescale(-places - 1)
+	if d.exp > -places {
+		return d.rescale(-places)
+	}
+	rounded, _ := d.roundMode(places, mode)
+	return rounded
+}
 
-	// add sign(d) * 0.5
-	if ret.value.Sign() < 0 {
-		ret.value.Sub(ret.value, fiveInt)
-	} else {
-		ret.value.Add(ret.value, fiveInt)
+// RoundSignificant rounds the decimal to n significant digits using the given
+// rounding mode, whatever its magnitude. The result keeps trailing zeros, so
+// that it always has exactly n significant digits. Zero is rounded to n-1
+// decimal places. It panics if n < 1.
+//
+// Example:
+//
+//	NewFromFloat(0.000123456).RoundSignificant(3, RoundHalfUp).String() // output: "0.000123"
+//	NewFromInt(123456000).RoundSignificant(3, RoundHalfUp).String()     // output: "123000000"
+//	NewFromFloat(-9.996).RoundSignificant(3, RoundHalfUp).String()      // output: "-10"
+//	NewFromFloat(1.5).RoundSignificant(3, RoundHalfUp).StringFixed(2)   // output: "1.50"
+func (d Decimal) RoundSignificant(n int, mode RoundingMode) Decimal {
+	if n < 1 {
+		panic(fmt.Sprintf("Cannot round to %d significant digits", n))
 	}
+	if d.form != formFinite {
+		return d
+	}
+	if d.Sign() == 0 {
+		return d.RoundMode(int32(n-1), mode)
+	}
+
+	places := int64(n) - int64(d.NumDigits()) - int64(d.Exponent())
+	if places > math.MaxInt32 || places < math.MinInt32 {
+		panic(fmt.Sprintf("exponent %v overflows an int32!", -places))
+	}
+	rounded := d.RoundMode(int32(places), mode)
+	if rounded.NumDigits() > n {
+		// rounded up to the next power of ten, e.g. 9.996 to 10.00
+		rounded = rounded.rescale(rounded.exp + 1)
+	}
+	return rounded
+}
 
-	// floor for positive numbers, ceil for negative numbers
-	_, m := ret.value.DivMod(ret.value, tenInt, new(big.Int))
-	ret.exp++
-	if ret.value.Sign() < 0 && m.Cmp(zeroInt) != 0 {
-		ret.value.Add(ret.value, oneInt)
+// Quantize returns d rounded or padded with zeros to the exponent of ref using
+// the given rounding mode, so that the result has the same number of decimal
+// places as ref, like quantize in the General Decimal Arithmetic specification.
+//
+// Quantizing an infinity to an infinity returns d, any other combination of an
+// infinity and a finite number returns NaN, as well as NaN operands.
+//
+// Example:
+//
+//	NewFromFloat(1.2345).Quantize(RequireFromString("0.01"), RoundHalfUp).String()    // output: "1.23"
+//	NewFromInt(5).Quantize(RequireFromString("1.000"), RoundHalfUp).StringFixed(3)    // output: "5.000"
+//	NewFromFloat(1234.5).Quantize(RequireFromString("1e2"), RoundHalfEven).String()   // output: "1200"
+func (d Decimal) Quantize(ref Decimal, mode RoundingMode) Decimal {
+	if d.form != formFinite || ref.form != formFinite {
+		if d.form == formInfinite && ref.form == formInfinite {
+			return d
+		}
+		return NaN()
+	}
+	if d.exp > ref.exp {
+		return d.rescale(ref.exp)
 	}
+	return d.RoundMode(-ref.exp, mode)
+}
 
-	return ret
+// QuantizeE is like Quantize, but returns ErrTooManyDigits when the coefficient
+// of the result would have more than maxDigits digits, e.g. when quantizing
+// 123.45 to 0.01 with maxDigits 4. Large results are rejected before they
+// are computed.
+func (d Decimal) QuantizeE(ref Decimal, mode RoundingMode, maxDigits int) (Decimal, error) {
+	if d.form == formFinite && ref.form == formFinite && d.exp > ref.exp && d.Sign() != 0 {
+		if int64(d.NumDigits())+int64(d.exp)-int64(ref.exp) > int64(maxDigits) {
+			return Decimal{}, ErrTooManyDigits
+		}
+	}
+	q := d.Quantize(ref, mode)
+	if q.form == formFinite && q.NumDigits() > maxDigits {
+		return Decimal{}, ErrTooManyDigits
+	}
+	return q, nil
 }
 
 // RoundCeil rounds the decimal towards +infinity.
@@ -1579,20 +2247,11 @@ func (d Decimal) Round(places int32) Decimal {
 //	NewFromFloat(1.1001).RoundCeil(2).String() // output: "1.11"
 //	NewFromFloat(-1.454).RoundCeil(1).String() // output: "-1.4"
 func (d Decimal) RoundCeil(places int32) Decimal {
-	if d.exp >= -places {
+	rounded, inexact := d.roundMode(places, RoundCeiling)
+	if !inexact {
 		return d
 	}
-
-	rescaled := d.rescale(-places)
-	if d.Equal(rescaled) {
-		return d
-	}
-
-	if d.value.Sign() > 0 {
-		rescaled.value.Add(rescaled.value, oneInt)
-	}
-
-	return rescaled
+	return rounded
 }
 
 // RoundFloor rounds the decimal towards -infinity.
@@ -1604,20 +2263,11 @@ func (d Decimal) RoundCeil(places int32) Decimal {
 //	NewFromFloat(1.1001).RoundFloor(2).String() // output: "1.1"
 //	NewFromFloat(-1.454).RoundFloor(1).String() // output: "-1.5"
 func (d Decimal) RoundFloor(places int32) Decimal {
-	if d.exp >= -places {
-		return d
-	}
-
-	rescaled := d.rescale(-places)
-	if d.Equal(rescaled) {
+	rounded, inexact := d.roundMode(places, RoundFloor)
+	if !inexact {
 		return d
 	}
-
-	if d.value.Sign() < 0 {
-		rescaled.value.Sub(rescaled.value, oneInt)
-	}
-
-	return rescaled
+	return rounded
 }
 
 // RoundUp rounds the decimal away from zero.
@@ -1629,22 +2279,11 @@ func (d Decimal) RoundFloor(places int32) Decimal {
 //	NewFromFloat(1.1001).RoundUp(2).String() // output: "1.11"
 //	NewFromFloat(-1.454).RoundUp(1).String() // output: "-1.5"
 func (d Decimal) RoundUp(places int32) Decimal {
-	if d.exp >= -places {
+	rounded, inexact := d.roundMode(places, RoundUp)
+	if !inexact {
 		return d
 	}
-
-	rescaled := d.rescale(-places)
-	if d.Equal(rescaled) {
-		return d
-	}
-
-	if d.value.Sign() > 0 {
-		rescaled.value.Add(rescaled.value, oneInt)
-	} else if d.value.Sign() < 0 {
-		rescaled.value.Sub(rescaled.value, oneInt)
-	}
-
-	return rescaled
+	return rounded
 }
 
 // RoundDown rounds the decimal towards zero.
@@ -1656,15 +2295,11 @@ func (d Decimal) RoundUp(places int32) Decimal {
 //	NewFromFloat(1.1001).RoundDown(2).String() // output: "1.1"
 //	NewFromFloat(-1.454).RoundDown(1).String() // output: "-1.4"
 func (d Decimal) RoundDown(places int32) Decimal {
-	if d.exp >= -places {
-		return d
-	}
-
-	rescaled := d.rescale(-places)
-	if d.Equal(rescaled) {
+	rounded, inexact := d.roundMode(places, RoundDown)
+	if !inexact {
 		return d
 	}
-	return rescaled
+	return rounded
 }
 
 // RoundBank rounds the decimal to places decimal places.
@@ -1682,20 +2317,7 @@ func (d Decimal) RoundDown(places int32) Decimal {
 //	NewFromFloat(5.55).RoundBank(1).String() // output: "5.6"
 //	NewFromFloat(555).RoundBank(-1).String() // output: "560"
 func (d Decimal) RoundBank(places int32) Decimal {
-
-	round := d.Round(places)
-	remainder := d.Sub(round).Abs()
-
-	half := New(5, -places-1)
-	if remainder.Cmp(half) == 0 && round.value.Bit(0) != 0 {
-		if round.value.Sign() < 0 {
-			round.value.Add(round.value, oneInt)
-		} else {
-			round.value.Sub(round.value, oneInt)
-		}
-	}
-
-	return round
+	return d.RoundMode(places, RoundHalfEven)
 }
 
 // RoundCash aka Cash/Penny/öre rounding rounds decimal to a specific
@@ -1711,33 +2333,41 @@ func (d Decimal) RoundBank(places int32) Decimal {
 //
 // For more details: https://en.wikipedia.org/wiki/Cash_rounding
 func (d Decimal) RoundCash(interval uint8) Decimal {
-	var iVal *big.Int
+	r, err := d.RoundCashE(interval)
+	if err != nil {
+		panic(fmt.Sprintf("Decimal does not support this Cash rounding interval `%d`. Supported: 5, 10, 25, 50, 100", interval))
+	}
+	return r
+}
+
+// RoundCashE is like RoundCash, but returns ErrInvalidInterval for an
+// unsupported interval instead of panicking.
+func (d Decimal) RoundCashE(interval uint8) (Decimal, error) {
+	var iVal int64
 	switch interval {
 	case 5:
-		iVal = twentyInt
+		iVal = 20
 	case 10:
-		iVal = tenInt
+		iVal = 10
 	case 25:
-		iVal = fourInt
+		iVal = 4
 	case 50:
-		iVal = twoInt
+		iVal = 2
 	case 100:
-		iVal = oneInt
+		iVal = 1
 	default:
-		panic(fmt.Sprintf("Decimal does not support this Cash rounding interval `%d`. Supported: 5, 10, 25, 50, 100", interval))
+		return Decimal{}, ErrInvalidInterval
 	}
 	dVal := Decimal{
-		value: iVal,
+		compact: iVal,
 	}
 
 	// TODO: optimize those calculations to reduce the high allocations (~29 allocs).
-	return d.Mul(dVal).Round(0).Div(dVal).Truncate(2)
+	return d.Mul(dVal).Round(0).Div(dVal).Truncate(2), nil
 }
 
 // Floor returns the nearest integer value less than or equal to d.
 func (d Decimal) Floor() Decimal {
-	d.ensureInitialized()
-
 	if d.exp >= 0 {
 		return d
 	}
@@ -1747,14 +2377,12 @@ func (d Decimal) Floor() Decimal {
 	// NOTE(vadim): must negate after casting to prevent int32 overflow
 	exp.Exp(exp, big.NewInt(-int64(d.exp)), nil)
 
-	z := new(big.Int).Div(d.value, exp)
-	return Decimal{value: z, exp: 0}
+	z := new(big.Int).Div(d.coef(), exp)
+	return newDecimal(z, 0)
 }
 
 // Ceil returns the nearest integer value greater than or equal to d.
 func (d Decimal) Ceil() Decimal {
-	d.ensureInitialized()
-
 	if d.exp >= 0 {
 		return d
 	}
@@ -1764,11 +2392,11 @@ func (d Decimal) Ceil() Decimal {
 	// NOTE(vadim): must negate after casting to prevent int32 overflow
 	exp.Exp(exp, big.NewInt(-int64(d.exp)), nil)
 
-	z, m := new(big.Int).DivMod(d.value, exp, new(big.Int))
+	z, m := new(big.Int).DivMod(d.coef(), exp, new(big.Int))
 	if m.Cmp(zeroInt) != 0 {
 		z.Add(z, oneInt)
 	}
-	return Decimal{value: z, exp: 0}
+	return newDecimal(z, 0)
 }
 
 // Truncate truncates off digits from the number, without rounding.
@@ -1779,13 +2407,30 @@ func (d Decimal) Ceil() Decimal {
 //
 //	decimal.NewFromString("123.456").Truncate(2).String() // "123.45"
 func (d Decimal) Truncate(precision int32) Decimal {
-	d.ensureInitialized()
 	if precision >= 0 && -precision > d.exp {
 		return d.rescale(-precision)
 	}
 	return d
 }
 
+// Normalize strips the trailing zeros of the coefficient into the exponent, so
+// that numerically equal decimals, e.g. 1.0 and 1.00, get the same coefficient
+// and exponent. Zero, including a negative zero, normalizes to 0.
+//
+// Example:
+//
+//	RequireFromString("1.500").Normalize() // coefficient 15, exponent -1
+//	RequireFromString("1200").Normalize()  // coefficient 12, exponent 2
+func (d Decimal) Normalize() Decimal {
+	if d.form != formFinite {
+		return d
+	}
+	if d.Sign() == 0 {
+		return Decimal{}
+	}
+	return d.reduce(math.MaxInt32)
+}
+
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (d *Decimal) UnmarshalJSON(decimalBytes []byte) error {
 	if string(decimalBytes) == "null" {
@@ -1801,9 +2446,10 @@ func (d *Decimal) UnmarshalJSON(decimalBytes []byte) error {
 }
 
 // MarshalJSON implements the json.Marshaler interface.
+// NaN and infinities are always quoted, as JSON numbers can't represent them.
 func (d Decimal) MarshalJSON() ([]byte, error) {
 	var str string
-	if MarshalJSONWithoutQuotes {
+	if MarshalJSONWithoutQuotes && d.form == formFinite {
 		str = d.String()
 	} else {
 		str = "\"" + d.String() + "\""
@@ -1822,21 +2468,47 @@ func (d *Decimal) UnmarshalBinary(data []byte) error {
 
 	// Extract the exponent
 	d.exp = int32(binary.BigEndian.Uint32(data[:4]))
+	d.compact, d.form, d.neg = 0, formFinite, false
+
+	// Extract a special value or the sign of a zero
+	if len(data) == 5 && data[4]&binarySpecial != 0 {
+		d.form = form(data[4]>>1) & 3
+		d.neg = data[4]&1 != 0
+		d.value = nil
+		return nil
+	}
 
 	// Extract the value
-	d.value = new(big.Int)
-	if err := d.value.GobDecode(data[4:]); err != nil {
+	value := new(big.Int)
+	if err := value.GobDecode(data[4:]); err != nil {
 		return fmt.Errorf("error decoding binary %v: %s", data, err)
 	}
+	d.value = nil
+	if value.IsInt64() {
+		d.compact = value.Int64()
+	} else {
+		d.value = value
+	}
 
 	return nil
 }
 
+// binarySpecial flags the byte that encodes the form and sign of a special
+// value or negative zero in place of the value. A gob encoded big.Int never
+// starts with such a byte.
+const binarySpecial = 0x80
+
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
 func (d Decimal) MarshalBinary() (data []byte, err error) {
 	// exp is written first, but encode value first to know output size
 	var valueData []byte
-	if valueData, err = d.value.GobEncode(); err != nil {
+	if d.form != formFinite || d.neg {
+		flags := binarySpecial | byte(d.form)<<1
+		if d.neg {
+			flags |= 1
+		}
+		valueData = []byte{flags}
+	} else if valueData, err = d.coef().GobEncode(); err != nil {
 		return nil, err
 	}
 
@@ -1854,12 +2526,12 @@ func (d *Decimal) Scan(value interface{}) error {
 	switch v := value.(type) {
 
 	case float32:
-		*d = NewFromFloat(float64(v))
+		*d = newFromFloatOrSpecial(float64(v))
 		return nil
 
 	case float64:
 		// numeric in sqlite3 sends us float64
-		*d = NewFromFloat(v)
+		*d = newFromFloatOrSpecial(v)
 		return nil
 
 	case int64:
@@ -1888,8 +2560,28 @@ func (d *Decimal) Scan(value interface{}) error {
 	}
 }
 
+// newFromFloatOrSpecial is NewFromFloat, but converts NaN and infinities to
+// their Decimal counterparts instead of panicking.
+func newFromFloatOrSpecial(f float64) Decimal {
+	switch {
+	case math.IsNaN(f):
+		return NaN()
+	case math.IsInf(f, 0):
+		return Inf(int(math.Copysign(1, f)))
+	}
+	return NewFromFloat(f)
+}
+
 // Value implements the driver.Valuer interface for database serialization.
+// Infinities are written as "Infinity" and "-Infinity", which databases like
+// PostgreSQL accept for numeric columns.
 func (d Decimal) Value() (driver.Value, error) {
+	if d.form == formInfinite {
+		if d.neg {
+			return "-Infinity", nil
+		}
+		return "Infinity", nil
+	}
 	return d.String(), nil
 }
 
@@ -1941,19 +2633,23 @@ func (d Decimal) StringScaled(exp int32) string {
 }
 
 func (d Decimal) string(trimTrailingZeros, avoidScientificNotation bool) string {
+	if d.form != formFinite {
+		return d.specialString()
+	}
+	// the sign of a negative zero isn't printed
+	d.neg = false
 	if d.exp == 0 {
-		return d.rescale(0).value.String()
+		return d.rescale(0).coefString()
 	}
 	if d.exp >= 0 {
 		if avoidScientificNotation {
-			return d.rescale(0).value.String()
+			return d.rescale(0).coefString()
 		} else {
 			return d.ScientificNotationString()
 		}
 	}
 
-	abs := new(big.Int).Abs(d.value)
-	str := abs.String()
+	str := d.absString()
 
 	var intPart, fractionalPart string
 
@@ -1985,7 +2681,7 @@ func (d Decimal) string(trimTrailingZeros, avoidScientificNotation bool) string
 		number += "." + fractionalPart
 	}
 
-	if d.value.Sign() < 0 {
+	if d.Sign() < 0 {
 		return "-" + number
 	}
 
@@ -1999,8 +2695,11 @@ func (d Decimal) string(trimTrailingZeros, avoidScientificNotation bool) string
 //
 // A zero, which has no significant digits, is simply serialized to "0".
 func (d Decimal) ScientificNotationString() string {
+	if d.form != formFinite {
+		return d.specialString()
+	}
 	exp := int(d.exp)
-	intStr := new(big.Int).Abs(d.value).String()
+	intStr := d.absString()
 	if intStr == "0" {
 		return intStr
 	}
@@ -2011,16 +2710,25 @@ func (d Decimal) ScientificNotationString() string {
 		exp = exp + len(intStr) - 1
 	}
 	number := string(first) + remaining + "E" + strconv.Itoa(exp)
-	if d.value.Sign() < 0 {
+	if d.Sign() < 0 {
 		return "-" + number
 	}
 	return number
 }
 
-func (d *Decimal) ensureInitialized() {
-	if d.value == nil {
-		d.value = new(big.Int)
+// specialString returns the string representation of NaN, sNaN or a signed
+// infinity.
+func (d Decimal) specialString() string {
+	switch d.form {
+	case formNaN:
+		return "NaN"
+	case formSNaN:
+		return "sNaN"
+	}
+	if d.neg {
+		return "-Inf"
 	}
+	return "Inf"
 }
 
 // Min returns the smallest Decimal that was passed in the arguments.
@@ -2030,6 +2738,7 @@ func (d *Decimal) ensureInitialized() {
 //	Min(arr[0], arr[1:]...)
 //
 // This makes it harder to accidentally call Min with 0 arguments.
+// If any of the arguments is NaN, Min returns NaN.
 func Min(first Decimal, rest ...Decimal) Decimal {
 	ans := first
 	for _, item := range rest {
@@ -2047,10 +2756,14 @@ func Min(first Decimal, rest ...Decimal) Decimal {
 //	Max(arr[0], arr[1:]...)
 //
 // This makes it harder to accidentally call Max with 0 arguments.
+// If any of the arguments is NaN, Max returns NaN.
 func Max(first Decimal, rest ...Decimal) Decimal {
 	ans := first
 	for _, item := range rest {
-		if item.Cmp(ans) > 0 {
+		if ans.IsNaN() {
+			break
+		}
+		if item.IsNaN() || item.Cmp(ans) > 0 {
 			ans = item
 		}
 	}
@@ -2076,9 +2789,6 @@ func Avg(first Decimal, rest ...Decimal) Decimal {
 
 // RescalePair rescales two decimals to common exponential value (minimal exp of both decimals)
 func RescalePair(d1 Decimal, d2 Decimal) (Decimal, Decimal) {
-	d1.ensureInitialized()
-	d2.ensureInitialized()
-
 	if d1.exp < d2.exp {
 		return d1, d2.rescale(d1.exp)
 	} else if d1.exp > d2.exp {
@@ -2203,7 +2913,11 @@ func (d NullDecimal) EncodeSpanner() (interface{}, error) {
 // Trig functions
 
 // Atan returns the arctangent, in radians, of x.
+// Atan(±Inf) is ±Pi/2.
 func (d Decimal) Atan() Decimal {
+	if d.IsNaN() {
+		return NaN()
+	}
 	if d.Equal(NewFromFloat(0.0)) {
 		return d
 	}
@@ -2235,17 +2949,26 @@ func (d Decimal) xatan() Decimal {
 // satan reduces its argument (known to be positive)
 // to the range [0, 0.66] and calls xatan.
 func (d Decimal) satan() Decimal {
-	Morebits := NewFromFloat(6.123233995736765886130e-17) // pi/2 = PIO2 + Morebits
-	Tan3pio8 := NewFromFloat(2.41421356237309504880)      // tan(3*pi/8)
-	pi := NewFromFloat(3.14159265358979323846264338327950288419716939937510582097494459)
+	Tan3pio8 := NewFromFloat(2.41421356237309504880) // tan(3*pi/8)
+	pi := pi.withPrecision(32)
 
 	if d.LessThanOrEqual(NewFromFloat(0.66)) {
 		return d.xatan()
 	}
 	if d.GreaterThan(Tan3pio8) {
-		return pi.Div(NewFromFloat(2.0)).Sub(NewFromFloat(1.0).Div(d).xatan()).Add(Morebits)
+		return pi.Mul(NewFromFloat(0.5)).Sub(NewFromFloat(1.0).Div(d).xatan())
+	}
+	return pi.Mul(NewFromFloat(0.25)).Add((d.Sub(NewFromFloat(1.0)).Div(d.Add(NewFromFloat(1.0)))).xatan())
+}
+
+// quarterPi returns Pi/4 with 32 places more than the integer digits of d,
+// for the reduction of the arguments of Sin, Cos and Tan.
+func (d Decimal) quarterPi() Decimal {
+	places := int32(32)
+	if adj := d.adjusted(); adj > 0 {
+		places += adj
 	}
-	return pi.Div(NewFromFloat(4.0)).Add((d.Sub(NewFromFloat(1.0)).Div(d.Add(NewFromFloat(1.0)))).xatan()).Add(NewFromFloat(0.5).Mul(Morebits))
+	return pi.withPrecision(places).Mul(NewFromFloat(0.25))
 }
 
 // sin coefficients
@@ -2259,11 +2982,11 @@ var _sin = [...]Decimal{
 }
 
 // Sin returns the sine of the radian argument x.
+// It returns NaN for NaN and infinities.
 func (d Decimal) Sin() Decimal {
-	PI4A := NewFromFloat(7.85398125648498535156e-1)                             // 0x3fe921fb40000000, Pi/4 split into three parts
-	PI4B := NewFromFloat(3.77489470793079817668e-8)                             // 0x3e64442d00000000,
-	PI4C := NewFromFloat(2.69515142907905952645e-15)                            // 0x3ce8469898cc5170,
-	M4PI := NewFromFloat(1.273239544735162542821171882678754627704620361328125) // 4/pi
+	if d.form != formFinite {
+		return NaN()
+	}
 
 	if d.Equal(NewFromFloat(0.0)) {
 		return d
@@ -2275,7 +2998,9 @@ func (d Decimal) Sin() Decimal {
 		sign = true
 	}
 
-	j := d.Mul(M4PI).IntPart()    // integer part of x/(Pi/4), as integer for tests on the phase angle
+	pi4 := d.quarterPi()
+	q, _ := d.QuoRem(pi4, 0)
+	j := q.IntPart()              // integer part of x/(Pi/4), as integer for tests on the phase angle
 	y := NewFromFloat(float64(j)) // integer part of x/(Pi/4), as float
 
 	// map zeros to origin
@@ -2289,7 +3014,7 @@ func (d Decimal) Sin() Decimal {
 		sign = !sign
 		j -= 4
 	}
-	z := d.Sub(y.Mul(PI4A)).Sub(y.Mul(PI4B)).Sub(y.Mul(PI4C)) // Extended precision modular arithmetic
+	z := d.Sub(y.Mul(pi4)) // Extended precision modular arithmetic
 	zz := z.Mul(z)
 
 	if j == 1 || j == 2 {
@@ -2315,12 +3040,11 @@ var _cos = [...]Decimal{
 }
 
 // Cos returns the cosine of the radian argument x.
+// It returns NaN for NaN and infinities.
 func (d Decimal) Cos() Decimal {
-
-	PI4A := NewFromFloat(7.85398125648498535156e-1)                             // 0x3fe921fb40000000, Pi/4 split into three parts
-	PI4B := NewFromFloat(3.77489470793079817668e-8)                             // 0x3e64442d00000000,
-	PI4C := NewFromFloat(2.69515142907905952645e-15)                            // 0x3ce8469898cc5170,
-	M4PI := NewFromFloat(1.273239544735162542821171882678754627704620361328125) // 4/pi
+	if d.form != formFinite {
+		return NaN()
+	}
 
 	// make argument positive
 	sign := false
@@ -2328,7 +3052,9 @@ func (d Decimal) Cos() Decimal {
 		d = d.Neg()
 	}
 
-	j := d.Mul(M4PI).IntPart()    // integer part of x/(Pi/4), as integer for tests on the phase angle
+	pi4 := d.quarterPi()
+	q, _ := d.QuoRem(pi4, 0)
+	j := q.IntPart()              // integer part of x/(Pi/4), as integer for tests on the phase angle
 	y := NewFromFloat(float64(j)) // integer part of x/(Pi/4), as float
 
 	// map zeros to origin
@@ -2346,7 +3072,7 @@ func (d Decimal) Cos() Decimal {
 		sign = !sign
 	}
 
-	z := d.Sub(y.Mul(PI4A)).Sub(y.Mul(PI4B)).Sub(y.Mul(PI4C)) // Extended precision modular arithmetic
+	z := d.Sub(y.Mul(pi4)) // Extended precision modular arithmetic
 	zz := z.Mul(z)
 
 	if j == 1 || j == 2 {
@@ -2375,12 +3101,11 @@ var _tanQ = [...]Decimal{
 }
 
 // Tan returns the tangent of the radian argument x.
+// It returns NaN for NaN and infinities.
 func (d Decimal) Tan() Decimal {
-
-	PI4A := NewFromFloat(7.85398125648498535156e-1)                             // 0x3fe921fb40000000, Pi/4 split into three parts
-	PI4B := NewFromFloat(3.77489470793079817668e-8)                             // 0x3e64442d00000000,
-	PI4C := NewFromFloat(2.69515142907905952645e-15)                            // 0x3ce8469898cc5170,
-	M4PI := NewFromFloat(1.273239544735162542821171882678754627704620361328125) // 4/pi
+	if d.form != formFinite {
+		return NaN()
+	}
 
 	if d.Equal(NewFromFloat(0.0)) {
 		return d
@@ -2393,7 +3118,9 @@ func (d Decimal) Tan() Decimal {
 		sign = true
 	}
 
-	j := d.Mul(M4PI).IntPart()    // integer part of x/(Pi/4), as integer for tests on the phase angle
+	pi4 := d.quarterPi()
+	q, _ := d.QuoRem(pi4, 0)
+	j := q.IntPart()              // integer part of x/(Pi/4), as integer for tests on the phase angle
 	y := NewFromFloat(float64(j)) // integer part of x/(Pi/4), as float
 
 	// map zeros to origin
@@ -2402,7 +3129,7 @@ func (d Decimal) Tan() Decimal {
 		y = y.Add(NewFromFloat(1.0))
 	}
 
-	z := d.Sub(y.Mul(PI4A)).Sub(y.Mul(PI4B)).Sub(y.Mul(PI4C)) // Extended precision modular arithmetic
+	z := d.Sub(y.Mul(pi4)) // Extended precision modular arithmetic
 	zz := z.Mul(z)
 
 	if zz.GreaterThan(NewFromFloat(1e-14)) {
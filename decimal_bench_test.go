@@ -204,6 +204,113 @@ func BenchmarkDecimal_Sub_same_precision(b *testing.B) {
 	}
 }
 
+// The benchmarks below compare coefficients stored inline with coefficients
+// too large for an int64, which take the big.Int code paths.
+
+func BenchmarkDecimal_Mul_compact(b *testing.B) {
+	d1 := RequireFromString("1234.5678")
+	d2 := RequireFromString("-0.0725")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d1.Mul(d2)
+	}
+}
+
+func BenchmarkDecimal_Mul_big(b *testing.B) {
+	d1 := RequireFromString("123456789012345678901234.5678")
+	d2 := RequireFromString("-0.0725")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d1.Mul(d2)
+	}
+}
+
+func BenchmarkDecimal_Cmp_compact(b *testing.B) {
+	d1 := RequireFromString("1234.5678")
+	d2 := RequireFromString("1234.56")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d1.Cmp(d2)
+	}
+}
+
+func BenchmarkDecimal_Cmp_big(b *testing.B) {
+	d1 := RequireFromString("123456789012345678901234.5678")
+	d2 := RequireFromString("123456789012345678901234.56")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d1.Cmp(d2)
+	}
+}
+
+func BenchmarkDecimal_StringFixed_compact(b *testing.B) {
+	d := RequireFromString("-1234.5678")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.StringFixed(2)
+	}
+}
+
+func benchmarkAmounts() []Decimal {
+	rng := rand.New(rand.NewSource(0xdead1337))
+	amounts := make([]Decimal, 1000)
+	for i := range amounts {
+		amounts[i] = New(rng.Int63n(10000000)-5000000, -2)
+	}
+	return amounts
+}
+
+func BenchmarkDecimal_Sum(b *testing.B) {
+	amounts := benchmarkAmounts()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum := Zero
+		for _, d := range amounts {
+			sum = sum.Add(d)
+		}
+	}
+}
+
+func BenchmarkDecimal_Sum_big(b *testing.B) {
+	amounts := benchmarkAmounts()
+	offset := RequireFromString("10000000000000000000000")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum := offset
+		for _, d := range amounts {
+			sum = sum.Add(d)
+		}
+	}
+}
+
+func BenchmarkBig_Sum(b *testing.B) {
+	amounts := benchmarkAmounts()
+	var sum, amount Big
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sum.Set(Zero)
+		for _, d := range amounts {
+			sum.Add(&sum, amount.Set(d))
+		}
+	}
+}
+
 func BenchmarkDecimal_IsInteger(b *testing.B) {
 	d := RequireFromString("12.000")
 
@@ -117,7 +117,7 @@ func TestNewFromFloat(t *testing.T) {
 		if d.String() != s {
 			t.Errorf("expected %s, got %s (float: %v) (%s, %d)",
 				s, d.String(), x.float,
-				d.value.String(), d.exp)
+				d.coefString(), d.exp)
 		}
 	}
 
@@ -152,8 +152,8 @@ func TestNewFromFloatRandom(t *testing.T) {
 		got := NewFromFloat(in)
 		if !want.Equal(got) {
 			t.Errorf("in: %v, expected %s (%s, %d), got %s (%s, %d) ",
-				in, want.String(), want.value.String(), want.exp,
-				got.String(), got.value.String(), got.exp)
+				in, want.String(), want.coefString(), want.exp,
+				got.String(), got.coefString(), got.exp)
 		}
 	}
 }
@@ -189,8 +189,8 @@ func TestNewFromFloat32Random(t *testing.T) {
 		got := NewFromFloat32(in)
 		if !want.Equal(got) {
 			t.Errorf("in: %v, expected %s (%s, %d), got %s (%s, %d) ",
-				in, want.String(), want.value.String(), want.exp,
-				got.String(), got.value.String(), got.exp)
+				in, want.String(), want.coefString(), want.exp,
+				got.String(), got.coefString(), got.exp)
 		}
 	}
 }
@@ -218,7 +218,7 @@ func TestNewFromString(t *testing.T) {
 		} else if d.String() != s {
 			t.Errorf("expected %s, got %s (%s, %d)",
 				s, d.String(),
-				d.value.String(), d.exp)
+				d.coefString(), d.exp)
 		}
 	}
 
@@ -230,7 +230,7 @@ func TestNewFromString(t *testing.T) {
 		} else if d.String() != s {
 			t.Errorf("expected %s, got %s (%s, %d)",
 				s, d.String(),
-				d.value.String(), d.exp)
+				d.coefString(), d.exp)
 		}
 	}
 
@@ -241,7 +241,7 @@ func TestNewFromString(t *testing.T) {
 		} else if d.String() != s {
 			t.Errorf("expected %s, got %s (%s, %d)",
 				s, d.String(),
-				d.value.String(), d.exp)
+				d.coefString(), d.exp)
 		}
 	}
 
@@ -396,7 +396,7 @@ func TestRequireFromString(t *testing.T) {
 	if d.String() != s {
 		t.Errorf("expected %s, got %s (%s, %d)",
 			s, d.String(),
-			d.value.String(), d.exp)
+			d.coefString(), d.exp)
 	}
 }
 
@@ -470,7 +470,7 @@ func TestNewFromFloatWithExponent(t *testing.T) {
 		if d.String() != s {
 			t.Errorf("expected %s, got %s (%s, %d)",
 				s, d.String(),
-				d.value.String(), d.exp)
+				d.coefString(), d.exp)
 		}
 	}
 
@@ -509,7 +509,7 @@ func TestNewFromInt(t *testing.T) {
 		if d.String() != s {
 			t.Errorf("expected %s, got %s (%s, %d)",
 				s, d.String(),
-				d.value.String(), d.exp)
+				d.coefString(), d.exp)
 		}
 	}
 }
@@ -535,7 +535,7 @@ func TestNewFromInt32(t *testing.T) {
 		if d.String() != s {
 			t.Errorf("expected %s, got %s (%s, %d)",
 				s, d.String(),
-				d.value.String(), d.exp)
+				d.coefString(), d.exp)
 		}
 	}
 }
@@ -554,7 +554,7 @@ func TestNewFromUint64(t *testing.T) {
 		if d.String() != s {
 			t.Errorf("expected %s, got %s (%s, %d)",
 				s, d.String(),
-				d.value.String(), d.exp)
+				d.coefString(), d.exp)
 		}
 	}
 }
@@ -576,7 +576,7 @@ func TestNewFromBigIntWithExponent(t *testing.T) {
 
 	// add negatives
 	for p, s := range tests {
-		if p.val.Cmp(Zero.value) > 0 {
+		if p.val.Sign() > 0 {
 			tests[Inp{p.val.Neg(p.val), p.exp}] = "-" + s
 		}
 	}
@@ -586,7 +586,7 @@ func TestNewFromBigIntWithExponent(t *testing.T) {
 		if d.String() != s {
 			t.Errorf("expected %s, got %s (%s, %d)",
 				s, d.String(),
-				d.value.String(), d.exp)
+				d.coefString(), d.exp)
 		}
 	}
 }
@@ -631,13 +631,13 @@ func TestNewFromBigRat(t *testing.T) {
 		if d.String() != s {
 			t.Errorf("expected %s, got %s (%s, %d)",
 				s, d.String(),
-				d.value.String(), d.exp)
+				d.coefString(), d.exp)
 		}
 	}
 }
 
 func TestCopy(t *testing.T) {
-	origin := New(1, 0)
+	origin := RequireFromString("123456789012345678901234567890")
 	cpy := origin.Copy()
 
 	if origin.value == cpy.value {
@@ -670,7 +670,7 @@ func TestJSON(t *testing.T) {
 		} else if doc.Amount.String() != s {
 			t.Errorf("expected %s, got %s (%s, %d)",
 				s, doc.Amount.String(),
-				doc.Amount.value.String(), doc.Amount.exp)
+				doc.Amount.coefString(), doc.Amount.exp)
 		}
 
 		out, err := json.Marshal(&doc)
@@ -703,7 +703,7 @@ func TestUnmarshalJSONNull(t *testing.T) {
 	} else if !doc.Amount.Equal(Zero) {
 		t.Errorf("expected Zero, got %s (%s, %d)",
 			doc.Amount.String(),
-			doc.Amount.value.String(), doc.Amount.exp)
+			doc.Amount.coefString(), doc.Amount.exp)
 	}
 }
 
@@ -744,7 +744,7 @@ func TestNullDecimalJSON(t *testing.T) {
 			if doc.Amount.Decimal.String() != s {
 				t.Errorf("expected %s, got %s (%s, %d)",
 					s, doc.Amount.Decimal.String(),
-					doc.Amount.Decimal.value.String(), doc.Amount.Decimal.exp)
+					doc.Amount.Decimal.coefString(), doc.Amount.Decimal.exp)
 			}
 		}
 
@@ -776,7 +776,7 @@ func TestNullDecimalJSON(t *testing.T) {
 	} else if doc.Amount.Valid {
 		t.Errorf("expected null value to have Valid = false, got Valid = true and Decimal = %s (%s, %d)",
 			doc.Amount.Decimal.String(),
-			doc.Amount.Decimal.value.String(), doc.Amount.Decimal.exp)
+			doc.Amount.Decimal.coefString(), doc.Amount.Decimal.exp)
 	}
 
 	expected := `{"amount":null}`
@@ -833,7 +833,7 @@ func TestXML(t *testing.T) {
 		} else if doc.Amount.String() != s {
 			t.Errorf("expected %s, got %s (%s, %d)",
 				s, doc.Amount.String(),
-				doc.Amount.value.String(), doc.Amount.exp)
+				doc.Amount.coefString(), doc.Amount.exp)
 		}
 
 		out, err := xml.Marshal(&doc)
@@ -881,7 +881,7 @@ func TestNullDecimalXML(t *testing.T) {
 		} else if doc.Amount.Decimal.String() != s {
 			t.Errorf("expected %s, got %s (%s, %d)",
 				s, doc.Amount.Decimal.String(),
-				doc.Amount.Decimal.value.String(), doc.Amount.Decimal.exp)
+				doc.Amount.Decimal.coefString(), doc.Amount.Decimal.exp)
 		}
 
 		out, err := xml.Marshal(&doc)
@@ -905,7 +905,7 @@ func TestNullDecimalXML(t *testing.T) {
 	} else if doc.Amount.Valid {
 		t.Errorf("expected null value to have Valid = false, got Valid = true and Decimal = %s (%s, %d)",
 			doc.Amount.Decimal.String(),
-			doc.Amount.Decimal.value.String(), doc.Amount.Decimal.exp)
+			doc.Amount.Decimal.coefString(), doc.Amount.Decimal.exp)
 	}
 
 	expected := `<account><amount></amount></account>`
@@ -924,7 +924,7 @@ func TestNullDecimalXML(t *testing.T) {
 	} else if doc.Amount.Valid {
 		t.Errorf("expected null value to have Valid = false, got Valid = true and Decimal = %s (%s, %d)",
 			doc.Amount.Decimal.String(),
-			doc.Amount.Decimal.value.String(), doc.Amount.Decimal.exp)
+			doc.Amount.Decimal.coefString(), doc.Amount.Decimal.exp)
 	}
 
 	expected = `<account><amount></amount></account>`
@@ -982,7 +982,7 @@ func TestDecimal_rescale(t *testing.T) {
 		if d.String() != s {
 			t.Errorf("expected %s, got %s (%s, %d)",
 				s, d.String(),
-				d.value.String(), d.exp)
+				d.coefString(), d.exp)
 		}
 
 		// test StringScaled
@@ -1632,6 +1632,503 @@ func TestDecimal_BankRoundAndStringFixed(t *testing.T) {
 	}
 }
 
+func TestDecimal_RoundModeAndStringFixed(t *testing.T) {
+	tests := []struct {
+		input    string
+		places   int32
+		mode     RoundingMode
+		expected string
+	}{
+		{"5.45", 1, RoundHalfUp, "5.5"},
+		{"5.45", 1, RoundHalfDown, "5.4"},
+		{"5.451", 1, RoundHalfDown, "5.5"},
+		{"5.45", 1, RoundHalfEven, "5.4"},
+		{"5.55", 1, RoundHalfEven, "5.6"},
+		{"5.45", 1, RoundHalfOdd, "5.5"},
+		{"5.55", 1, RoundHalfOdd, "5.5"},
+		{"-5.41", 1, RoundCeiling, "-5.4"},
+		{"-5.41", 1, RoundFloor, "-5.5"},
+		{"5.41", 1, RoundUp, "5.5"},
+		{"-5.49", 1, RoundDown, "-5.4"},
+		{"5.01", 1, Round05Up, "5.1"},
+		{"5.51", 1, Round05Up, "5.6"},
+		{"5.21", 1, Round05Up, "5.2"},
+		{"545", -1, RoundHalfEven, "540"},
+		{"545", -2, RoundCeiling, "600"},
+		{"5.45", 3, RoundDown, "5.450"},
+		{"0", 2, RoundUp, "0.00"},
+	}
+
+	for _, test := range tests {
+		d := RequireFromString(test.input)
+		expected := RequireFromString(test.expected)
+
+		got := d.RoundMode(test.places, test.mode)
+		if !got.Equal(expected) {
+			t.Errorf("Rounding %s to %d places with %s, got %s, expected %s",
+				d, test.places, test.mode, got, expected)
+		}
+		if got.Exponent() != -test.places {
+			t.Errorf("Rounding %s to %d places with %s, got exponent %d, expected %d",
+				d, test.places, test.mode, got.Exponent(), -test.places)
+		}
+
+		if test.places >= 0 {
+			gotStr := d.StringFixedMode(test.places, test.mode)
+			if gotStr != test.expected {
+				t.Errorf("(%s).StringFixedMode(%d, %s): got %s, expected %s",
+					d, test.places, test.mode, gotStr, test.expected)
+			}
+		}
+	}
+
+	// RoundMode unifies the specialized rounding methods
+	for _, input := range []string{"1.454", "-1.454", "1.455", "-1.455", "1.465", "-1.465", "545", "-555", "0.0001", "-0.0001", "0"} {
+		d := RequireFromString(input)
+		for places := int32(-3); places <= 4; places++ {
+			for _, c := range []struct {
+				mode   RoundingMode
+				legacy Decimal
+			}{
+				{RoundHalfUp, d.Round(places)},
+				{RoundHalfEven, d.RoundBank(places)},
+				{RoundCeiling, d.RoundCeil(places)},
+				{RoundFloor, d.RoundFloor(places)},
+				{RoundUp, d.RoundUp(places)},
+				{RoundDown, d.RoundDown(places)},
+			} {
+				if got := d.RoundMode(places, c.mode); !got.Equal(c.legacy) {
+					t.Errorf("Rounding %s to %d places with %s, got %s, expected %s",
+						d, places, c.mode, got, c.legacy)
+				}
+			}
+			if places >= 0 {
+				if got, want := d.StringFixedMode(places, RoundHalfUp), d.StringFixed(places); got != want {
+					t.Errorf("(%s).StringFixedMode(%d, HalfUp): got %s, expected %s", d, places, got, want)
+				}
+				if got, want := d.StringFixedMode(places, RoundHalfEven), d.StringFixedBank(places); got != want {
+					t.Errorf("(%s).StringFixedMode(%d, HalfEven): got %s, expected %s", d, places, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestDecimal_Quantize(t *testing.T) {
+	tests := []struct {
+		input, ref string
+		mode       RoundingMode
+		want       string
+	}{
+		{"1.2345", "0.01", RoundHalfUp, "1.23"},
+		{"1.2355", "0.01", RoundHalfUp, "1.24"},
+		{"-1.2355", "0.00", RoundDown, "-1.23"},
+		{"5", "1.000", RoundHalfUp, "5.000"},
+		{"-5.1", "9.999", RoundHalfUp, "-5.100"},
+		{"1234.5", "1e2", RoundHalfEven, "1200"},
+		{"1250", "1e2", RoundHalfEven, "1200"},
+		{"1250", "1e2", RoundHalfUp, "1300"},
+		{"0.005", "0.01", RoundHalfEven, "0.00"},
+		{"0", "0.001", RoundHalfUp, "0.000"},
+		{"12", "7", RoundHalfUp, "12"},
+	}
+	for _, test := range tests {
+		d, ref := RequireFromString(test.input), RequireFromString(test.ref)
+		got := d.Quantize(ref, test.mode)
+		if got.Exponent() != ref.Exponent() {
+			t.Errorf("expected exponent %d for %s quantized to %s, got %d", ref.Exponent(), d, ref, got.Exponent())
+		}
+		if s := got.string(false, true); s != test.want {
+			t.Errorf("expected %s for %s quantized to %s with %s, got %s", test.want, d, ref, test.mode, s)
+		}
+	}
+
+	specials := []struct {
+		d, ref Decimal
+		want   string
+	}{
+		{Inf(-1), Inf(1), "-Inf"},
+		{Inf(1), New(1, -2), "NaN"},
+		{New(1, 0), Inf(1), "NaN"},
+		{NaN(), New(1, -2), "NaN"},
+		{New(1, 0), NaN(), "NaN"},
+	}
+	for _, test := range specials {
+		if got := test.d.Quantize(test.ref, RoundHalfUp); got.String() != test.want {
+			t.Errorf("expected %s for %s quantized to %s, got %s", test.want, test.d, test.ref, got)
+		}
+	}
+}
+
+func TestDecimal_QuantizeE(t *testing.T) {
+	cent := New(1, -2)
+	if got, err := RequireFromString("123.456").QuantizeE(cent, RoundHalfUp, 5); err != nil || got.String() != "123.46" {
+		t.Errorf("expected 123.46, got %s and %v", got, err)
+	}
+	if _, err := RequireFromString("123.456").QuantizeE(cent, RoundHalfUp, 4); err != ErrTooManyDigits {
+		t.Errorf("expected %v, got %v", ErrTooManyDigits, err)
+	}
+	if _, err := RequireFromString("999.999").QuantizeE(cent, RoundHalfUp, 5); err != ErrTooManyDigits {
+		t.Errorf("expected %v, got %v", ErrTooManyDigits, err)
+	}
+	if _, err := New(1, math.MaxInt32-1).QuantizeE(cent, RoundHalfUp, 18); err != ErrTooManyDigits {
+		t.Errorf("expected %v, got %v", ErrTooManyDigits, err)
+	}
+	if got, err := New(0, 10).QuantizeE(cent, RoundHalfUp, 1); err != nil || got.StringFixed(2) != "0.00" {
+		t.Errorf("expected 0.00, got %s and %v", got, err)
+	}
+	if got, err := Inf(1).QuantizeE(cent, RoundHalfUp, 1); err != nil || !got.IsNaN() {
+		t.Errorf("expected NaN, got %s and %v", got, err)
+	}
+}
+
+func TestDecimal_RoundSignificant(t *testing.T) {
+	tests := []struct {
+		input string
+		n     int
+		mode  RoundingMode
+		want  string
+	}{
+		{"0.000123456", 3, RoundHalfUp, "0.000123"},
+		{"0.000123556", 3, RoundHalfUp, "0.000124"},
+		{"-0.000123556", 3, RoundDown, "-0.000123"},
+		{"-0.000123556", 3, RoundFloor, "-0.000124"},
+		{"123456000", 3, RoundHalfUp, "123000000"},
+		{"123556000", 3, RoundHalfUp, "124000000"},
+		{"123456000", 1, RoundUp, "200000000"},
+		{"1.5", 3, RoundHalfUp, "1.50"},
+		{"9.996", 3, RoundHalfUp, "10.0"},
+		{"-9.996", 3, RoundHalfUp, "-10.0"},
+		{"999.5", 3, RoundHalfEven, "1000"},
+		{"999.5", 3, RoundDown, "999"},
+		{"0.0000999", 1, RoundHalfUp, "0.0001"},
+		{"2.5", 1, RoundHalfEven, "2"},
+		{"3.5", 1, RoundHalfEven, "4"},
+		{"0", 3, RoundHalfUp, "0.00"},
+		{"0.000", 1, RoundHalfUp, "0"},
+		{"-0", 2, RoundHalfUp, "0.0"},
+		{"12345678901234567890.123", 5, RoundHalfUp, "12346000000000000000"},
+		{"1e-30", 2, RoundHalfUp, "0.0000000000000000000000000000010"},
+	}
+	for _, test := range tests {
+		d := RequireFromString(test.input)
+		if s := d.RoundSignificant(test.n, test.mode).string(false, true); s != test.want {
+			t.Errorf("expected %s for %s rounded to %d significant digits with %s, got %s",
+				test.want, test.input, test.n, test.mode, s)
+		}
+		if test.mode == RoundHalfUp && d.StringSignificant(test.n) != test.want {
+			t.Errorf("expected %s for %s with %d significant digits, got %s",
+				test.want, test.input, test.n, d.StringSignificant(test.n))
+		}
+	}
+
+	for _, d := range []Decimal{NaN(), Inf(1), Inf(-1)} {
+		if got := d.RoundSignificant(2, RoundHalfUp); got.String() != d.String() {
+			t.Errorf("expected %s, got %s", d, got)
+		}
+	}
+	if !didPanic(func() { New(1, 0).RoundSignificant(0, RoundHalfUp) }) {
+		t.Errorf("expected panic for 0 significant digits")
+	}
+}
+
+func TestDecimal_SpecialValuesString(t *testing.T) {
+	for _, testCase := range []struct {
+		Input    string
+		Expected string
+	}{
+		{"NaN", "NaN"},
+		{"nan", "NaN"},
+		{"-NaN", "NaN"},
+		{"sNaN", "sNaN"},
+		{"Inf", "Inf"},
+		{"+inf", "Inf"},
+		{"Infinity", "Inf"},
+		{"-Infinity", "-Inf"},
+		{"-INF", "-Inf"},
+		{"-0", "0"},
+		{"-0.00", "0"},
+		{"-0e3", "0"},
+	} {
+		d, err := NewFromString(testCase.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.String() != testCase.Expected {
+			t.Errorf("expected %s, got %s, for %q", testCase.Expected, d, testCase.Input)
+		}
+	}
+
+	// the sign of a negative zero is kept internally, but not formatted
+	z := RequireFromString("-0.00")
+	if !z.Signbit() {
+		t.Errorf("expected the sign of -0.00 to be kept")
+	}
+	for _, testCase := range []struct {
+		Got      string
+		Expected string
+	}{
+		{z.StringFixed(2), "0.00"},
+		{z.StringFixedBank(0), "0"},
+		{z.StringSignificant(3), "0.00"},
+		{z.StringFixedCash(5), "0.00"},
+		{z.ScientificNotationString(), "0"},
+	} {
+		if testCase.Got != testCase.Expected {
+			t.Errorf("expected %s, got %s", testCase.Expected, testCase.Got)
+		}
+	}
+	if b, err := z.MarshalJSON(); err != nil || string(b) != `"0"` {
+		t.Errorf("expected \"0\", got %s, %v", b, err)
+	}
+	if v, err := z.Value(); err != nil || v != "0" {
+		t.Errorf("expected 0, got %v, %v", v, err)
+	}
+
+	for _, input := range []string{"Infinit", "NaN1", "-", "in"} {
+		if _, err := NewFromString(input); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+}
+
+func TestDecimal_SpecialValuesPredicates(t *testing.T) {
+	for _, testCase := range []struct {
+		D          Decimal
+		NaN        bool
+		Inf        int
+		Signbit    bool
+		Zero       bool
+		Sign       int
+		IsInteger  bool
+		Float64    float64
+		FloatIsNaN bool
+	}{
+		{NaN(), true, 0, false, false, 0, false, 0, true},
+		{SignalingNaN(), true, 0, false, false, 0, false, 0, true},
+		{Inf(1), false, 1, false, false, 1, false, math.Inf(1), false},
+		{Inf(-1), false, -1, true, false, -1, false, math.Inf(-1), false},
+		{RequireFromString("-0"), false, 0, true, true, 0, true, math.Copysign(0, -1), false},
+		{Decimal{}, false, 0, false, true, 0, true, 0, false},
+		{New(-3, 0), false, 0, true, false, -1, true, -3, false},
+	} {
+		d := testCase.D
+		if d.IsNaN() != testCase.NaN {
+			t.Errorf("%s: expected IsNaN %v", d, testCase.NaN)
+		}
+		if d.IsInf(testCase.Inf) != (testCase.Inf != 0) || d.IsInf(-testCase.Inf) || d.IsFinite() != (!testCase.NaN && testCase.Inf == 0) {
+			t.Errorf("%s: unexpected IsInf or IsFinite result", d)
+		}
+		if d.Signbit() != testCase.Signbit {
+			t.Errorf("%s: expected Signbit %v", d, testCase.Signbit)
+		}
+		if d.IsZero() != testCase.Zero || d.Sign() != testCase.Sign || d.IsInteger() != testCase.IsInteger {
+			t.Errorf("%s: unexpected IsZero, Sign or IsInteger result", d)
+		}
+		f, exact := d.Float64()
+		if !exact || math.IsNaN(f) != testCase.FloatIsNaN || (!testCase.FloatIsNaN && (f != testCase.Float64 || math.Signbit(f) != math.Signbit(testCase.Float64))) {
+			t.Errorf("%s: expected %v, got %v, %v", d, testCase.Float64, f, exact)
+		}
+		if !d.IsFinite() && (d.IntPart() != 0 || d.BigInt().Sign() != 0) {
+			t.Errorf("%s: expected integer part 0", d)
+		}
+	}
+}
+
+func TestDecimal_SpecialValuesArithmetic(t *testing.T) {
+	for _, testCase := range []struct {
+		D   string
+		D2  string
+		Add string
+		Sub string
+		Mul string
+		Div string
+	}{
+		{"Inf", "2", "Inf", "Inf", "Inf", "Inf"},
+		{"Inf", "-2", "Inf", "Inf", "-Inf", "-Inf"},
+		{"-2", "Inf", "Inf", "-Inf", "-Inf", "0"},
+		{"Inf", "Inf", "Inf", "NaN", "Inf", "NaN"},
+		{"Inf", "-Inf", "NaN", "Inf", "-Inf", "NaN"},
+		{"-Inf", "0", "-Inf", "-Inf", "NaN", "-Inf"},
+		{"NaN", "1", "NaN", "NaN", "NaN", "NaN"},
+		{"1", "sNaN", "NaN", "NaN", "NaN", "NaN"},
+		{"1", "0", "1", "1", "0", "Inf"},
+		{"1", "-0", "1", "1", "0", "-Inf"},
+		{"-1", "0", "-1", "-1", "0", "-Inf"},
+		{"0", "0", "0", "0", "0", "NaN"},
+	} {
+		d := RequireFromString(testCase.D)
+		d2 := RequireFromString(testCase.D2)
+		for _, op := range []struct {
+			Name     string
+			Result   Decimal
+			Expected string
+		}{
+			{"+", d.Add(d2), testCase.Add},
+			{"-", d.Sub(d2), testCase.Sub},
+			{"*", d.Mul(d2), testCase.Mul},
+			{"/", d.Div(d2), testCase.Div},
+		} {
+			if op.Result.String() != op.Expected {
+				t.Errorf("expected %s, got %s, for %s %s %s", op.Expected, op.Result, d, op.Name, d2)
+			}
+		}
+	}
+
+	for _, testCase := range []struct {
+		Result   Decimal
+		Expected string
+	}{
+		{Inf(-1).Neg(), "Inf"},
+		{Inf(-1).Abs(), "Inf"},
+		{RequireFromString("-0").Neg(), "0"},
+		{RequireFromString("-0").Abs(), "0"},
+		{Inf(1).Round(2), "Inf"},
+		{NaN().Floor(), "NaN"},
+		{Inf(-1).Truncate(2), "-Inf"},
+		{Inf(1).Shift(3), "Inf"},
+		{Inf(1).RoundCash(5), "Inf"},
+		{New(0, 0).Pow(New(0, 0)), "NaN"},
+		{New(0, 0).Pow(New(-2, 0)), "Inf"},
+		{New(-2, 0).Pow(New(5, -1)), "NaN"},
+		{Inf(-1).Pow(New(3, 0)), "-Inf"},
+		{Inf(-1).Pow(New(2, 0)), "Inf"},
+		{Inf(-1).Pow(New(-3, 0)), "0"},
+		{New(2, 0).Pow(Inf(-1)), "0"},
+		{New(5, -1).Pow(Inf(-1)), "Inf"},
+		{NaN().Pow(New(0, 0)), "NaN"},
+		{Inf(1).Pow(New(0, 0)), "1"},
+		{Inf(1).Atan().Round(10), "1.5707963268"},
+		{Inf(1).Sin(), "NaN"},
+		{Min(New(1, 0), NaN(), New(-1, 0)), "NaN"},
+		{Max(New(1, 0), NaN(), New(2, 0)), "NaN"},
+		{Max(NaN(), New(2, 0)), "NaN"},
+		{Max(New(1, 0), Inf(1)), "Inf"},
+	} {
+		if testCase.Result.String() != testCase.Expected {
+			t.Errorf("expected %s, got %s", testCase.Expected, testCase.Result)
+		}
+	}
+
+	for _, d := range []Decimal{New(-2, 0).Div(Inf(1)), Inf(-1).Pow(New(-3, 0))} {
+		if !d.IsZero() || !d.Signbit() {
+			t.Errorf("expected a negative zero, got %s with sign %v", d, d.Signbit())
+		}
+	}
+
+	if r, err := New(0, 0).PowInt32(-2); err != nil || !r.IsInf(1) {
+		t.Errorf("expected Inf, got %s and %v", r, err)
+	}
+	if r, err := Inf(1).Ln(2); err != nil || !r.IsInf(1) {
+		t.Errorf("expected Inf, got %s and %v", r, err)
+	}
+	if r, err := Inf(-1).ExpTaylor(2); err != nil || !r.IsZero() {
+		t.Errorf("expected 0, got %s and %v", r, err)
+	}
+	if _, err := New(0, 0).PowWithPrecision(New(-1, 0), 2); err == nil {
+		t.Errorf("expected error for 0**-1")
+	}
+	if !didPanic(func() { New(1, 0).QuoRem(New(0, 0), 0) }) {
+		t.Errorf("expected panic for QuoRem by zero")
+	}
+	if q, r := New(3, 0).QuoRem(Inf(1), 0); !q.IsZero() || !r.Equal(New(3, 0)) {
+		t.Errorf("expected 0 and 3, got %s and %s", q, r)
+	}
+}
+
+func TestDecimal_SpecialValuesCmp(t *testing.T) {
+	// NaN < -Inf < finite < +Inf
+	ordered := []Decimal{NaN(), Inf(-1), New(-1, 0), New(0, 0), New(1, 0), Inf(1)}
+	for i, a := range ordered {
+		for j, b := range ordered {
+			if got, want := a.Cmp(b), New(int64(i-j), 0).Sign(); got != want {
+				t.Errorf("expected %d, got %d, for %s cmp %s", want, got, a, b)
+			}
+		}
+		if nan := NaN(); a.Equal(nan) || a.LessThan(nan) || a.LessThanOrEqual(nan) ||
+			a.GreaterThan(nan) || a.GreaterThanOrEqual(nan) || nan.GreaterThan(a) || nan.LessThanOrEqual(a) {
+			t.Errorf("expected all comparisons of %s with NaN to be false", a)
+		}
+	}
+
+	if !RequireFromString("-0").Equal(Zero) || !Inf(1).Equal(Inf(1)) || Inf(1).Equal(Inf(-1)) {
+		t.Errorf("unexpected equality of signed zeros or infinities")
+	}
+}
+
+func TestDecimal_SpecialValuesEncoding(t *testing.T) {
+	for _, d := range []Decimal{NaN(), SignalingNaN(), Inf(1), Inf(-1), RequireFromString("-0.00"), New(-12, -1), New(0, 0)} {
+		for _, withoutQuotes := range []bool{false, true} {
+			MarshalJSONWithoutQuotes = withoutQuotes
+			b, err := json.Marshal(d)
+			MarshalJSONWithoutQuotes = false
+			if err != nil {
+				t.Fatal(err)
+			}
+			if d.IsFinite() == (b[0] == '"') && withoutQuotes {
+				t.Errorf("unexpected quoting %s of %s", b, d)
+			}
+			var got Decimal
+			if err := json.Unmarshal(b, &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != d.String() {
+				t.Errorf("expected %s, got %s, after JSON roundtrip", d, got)
+			}
+		}
+
+		b, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := New(7, 3)
+		if err := got.UnmarshalBinary(b); err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != d.String() {
+			t.Errorf("expected %s, got %s, after binary roundtrip", d, got)
+		}
+	}
+
+	for _, testCase := range []struct {
+		D        Decimal
+		Expected driver.Value
+	}{
+		{Inf(1), "Infinity"},
+		{Inf(-1), "-Infinity"},
+		{NaN(), "NaN"},
+	} {
+		v, err := testCase.D.Value()
+		if err != nil || v != testCase.Expected {
+			t.Errorf("expected %v, got %v and %v", testCase.Expected, v, err)
+		}
+		var got Decimal
+		if err := got.Scan(v); err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != testCase.D.String() {
+			t.Errorf("expected %s, got %s, after Scan", testCase.D, got)
+		}
+	}
+
+	for _, testCase := range []struct {
+		Value    interface{}
+		Expected string
+	}{
+		{math.NaN(), "NaN"},
+		{math.Inf(-1), "-Inf"},
+		{float32(math.Inf(1)), "Inf"},
+	} {
+		var got Decimal
+		if err := got.Scan(testCase.Value); err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != testCase.Expected {
+			t.Errorf("expected %s, got %s, for Scan(%v)", testCase.Expected, got, testCase.Value)
+		}
+	}
+}
+
 func TestDecimal_Uninitialized(t *testing.T) {
 	a := Decimal{}
 	b := Decimal{}
@@ -1969,7 +2466,7 @@ func TestDecimal_QuoRem(t *testing.T) {
 			t.Errorf("remainder too large: d=%v, d2= %v, prec=%d, q=%v, r=%v",
 				d, d2, prec, q, r)
 		}
-		if r.value.Sign()*d.value.Sign() < 0 {
+		if r.Sign()*d.Sign() < 0 {
 			t.Errorf("signum of divisor and rest do not match: d=%v, d2= %v, prec=%d, q=%v, r=%v",
 				d, d2, prec, q, r)
 		}
@@ -2034,7 +2531,7 @@ func TestDecimal_QuoRem2(t *testing.T) {
 				d, d2, prec, q, r)
 		}
 		// rule 4: r and d have the same sign
-		if r.value.Sign()*d.value.Sign() < 0 {
+		if r.Sign()*d.Sign() < 0 {
 			t.Errorf("signum of divisor and rest do not match, "+
 				"d=%v, d2=%v, prec=%d, q=%v, r=%v",
 				d, d2, prec, q, r)
@@ -2061,7 +2558,7 @@ func (d Decimal) DivOld(d2 Decimal, prec int) Decimal {
 }
 
 func sign(d Decimal) int {
-	return d.value.Sign()
+	return d.Sign()
 }
 
 // rules for rounded divide, rounded to integer
@@ -2136,6 +2633,54 @@ func TestDecimal_DivRound2(t *testing.T) {
 	}
 }
 
+func TestDecimal_DivRoundMode(t *testing.T) {
+	cases := []struct {
+		d      string
+		d2     string
+		prec   int32
+		mode   RoundingMode
+		result string
+	}{
+		{"1", "8", 2, RoundHalfUp, "0.13"},
+		{"1", "8", 2, RoundHalfDown, "0.12"},
+		{"1", "8", 2, RoundHalfEven, "0.12"},
+		{"3", "8", 2, RoundHalfEven, "0.38"},
+		{"1", "8", 2, RoundHalfOdd, "0.13"},
+		{"-2", "3", 2, RoundCeiling, "-0.66"},
+		{"-2", "3", 2, RoundFloor, "-0.67"},
+		{"2", "3", 2, RoundUp, "0.67"},
+		{"2", "3", 2, RoundDown, "0.66"},
+		{"1", "3", 2, Round05Up, "0.33"},
+		{"1", "-6", 1, Round05Up, "-0.1"},
+		{"1001", "20", 0, Round05Up, "51"},
+		{"545", "1", -1, RoundHalfEven, "540"},
+	}
+	for _, s := range cases {
+		d, _ := NewFromString(s.d)
+		d2, _ := NewFromString(s.d2)
+		result, _ := NewFromString(s.result)
+		q := d.DivRoundMode(d2, s.prec, s.mode)
+		if !q.Equal(result) || q.Exponent() != -s.prec {
+			t.Errorf("rounded division wrong %s / %s scale %d mode %s = %s, got %v", s.d, s.d2, s.prec, s.mode, s.result, q)
+		}
+	}
+
+	// compare with rounding of the exact quotient
+	modes := []RoundingMode{RoundHalfUp, RoundHalfDown, RoundHalfEven, RoundHalfOdd, RoundCeiling, RoundFloor, RoundUp, RoundDown, Round05Up}
+	for i, tc := range createDivTestCases() {
+		if sign(tc.d2) == 0 || i%7 != 0 {
+			continue
+		}
+		exact := tc.d.DivRound(tc.d2, tc.prec+40)
+		for _, mode := range modes {
+			q := tc.d.DivRoundMode(tc.d2, tc.prec, mode)
+			if want := exact.RoundMode(tc.prec, mode); !q.Equal(want) {
+				t.Errorf("rounded division wrong %v / %v scale %d mode %s = %v, got %v", tc.d, tc.d2, tc.prec, mode, want, q)
+			}
+		}
+	}
+}
+
 func TestDecimal_RoundCash(t *testing.T) {
 	tests := []struct {
 		d        string
@@ -2247,6 +2792,222 @@ func TestDecimal_Mod(t *testing.T) {
 	}
 }
 
+func TestDecimal_FloorEuclidRem(t *testing.T) {
+	tests := []struct {
+		a, b                 string
+		divFloor, modFloor   string
+		divEuclid, modEuclid string
+		rem                  string
+	}{
+		{"-7", "2", "-4", "1", "-4", "1", "1"},
+		{"7", "2", "3", "1", "3", "1", "-1"},
+		{"7", "-2", "-4", "-1", "-3", "1", "-1"},
+		{"-7", "-2", "3", "-1", "4", "1", "1"},
+		{"7.5", "-2", "-4", "-0.5", "-3", "1.5", "-0.5"},
+		{"-7.5", "2", "-4", "0.5", "-4", "0.5", "0.5"},
+		{"6", "3", "2", "0", "2", "0", "0"},
+		{"-6", "3", "-2", "0", "-2", "0", "0"},
+		{"0", "-5", "0", "0", "0", "0", "0"},
+		{"1.23", "0.1", "12", "0.03", "12", "0.03", "0.03"},
+		{"-1.23", "0.1", "-13", "0.07", "-13", "0.07", "-0.03"},
+		{"1e20", "-0.003", "-33333333333333333333334", "-0.002", "-33333333333333333333333", "0.001", "0.001"},
+		{"-0.0001", "7", "-1", "6.9999", "-1", "6.9999", "-0.0001"},
+		{"123456789012345678901234567890", "-987654321.123", "-124999998857870312545", "-973237498.035", "-124999998857870312544", "14416823.088", "14416823.088"},
+		{"5", "3", "1", "2", "1", "2", "-1"},
+		{"-5", "3", "-2", "1", "-2", "1", "1"},
+		{"2.5", "1", "2", "0.5", "2", "0.5", "0.5"},
+		{"3.5", "1", "3", "0.5", "3", "0.5", "-0.5"},
+		{"-2.5", "1", "-3", "0.5", "-3", "0.5", "-0.5"},
+		{"0.75", "0.5", "1", "0.25", "1", "0.25", "-0.25"},
+		{"1e-10", "3", "0", "0.0000000001", "0", "0.0000000001", "0.0000000001"},
+	}
+	for _, test := range tests {
+		a, b := RequireFromString(test.a), RequireFromString(test.b)
+		if got := a.DivFloor(b).String(); got != test.divFloor {
+			t.Errorf("expected DivFloor(%s, %s) = %s, got %s", a, b, test.divFloor, got)
+		}
+		if got := a.ModFloor(b).String(); got != test.modFloor {
+			t.Errorf("expected ModFloor(%s, %s) = %s, got %s", a, b, test.modFloor, got)
+		}
+		if got := a.DivEuclid(b).String(); got != test.divEuclid {
+			t.Errorf("expected DivEuclid(%s, %s) = %s, got %s", a, b, test.divEuclid, got)
+		}
+		if got := a.ModEuclid(b).String(); got != test.modEuclid {
+			t.Errorf("expected ModEuclid(%s, %s) = %s, got %s", a, b, test.modEuclid, got)
+		}
+		if got := a.Rem(b).String(); got != test.rem {
+			t.Errorf("expected Rem(%s, %s) = %s, got %s", a, b, test.rem, got)
+		}
+	}
+
+	// an infinite divisor rounds the quotient of a finite dividend like math
+	if q, r := New(-5, 0).DivFloor(Inf(1)), New(-5, 0).ModFloor(Inf(1)); q.String() != "-1" || !r.IsInf(1) {
+		t.Errorf("expected -1 and Inf, got %s and %s", q, r)
+	}
+	if r := New(-5, 0).Rem(Inf(1)); r.String() != "-5" {
+		t.Errorf("expected -5, got %s", r)
+	}
+	if r := Inf(1).ModEuclid(New(2, 0)); !r.IsNaN() {
+		t.Errorf("expected NaN, got %s", r)
+	}
+	for _, f := range []func(){
+		func() { New(1, 0).DivFloor(Zero) },
+		func() { New(1, 0).ModEuclid(Zero) },
+		func() { New(1, 0).Rem(Zero) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic for division by zero")
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestDecimal_DivExact(t *testing.T) {
+	tests := []struct {
+		a, b, want string
+	}{
+		{"10", "4", "2.5"},
+		{"1", "8", "0.125"},
+		{"-1", "16", "-0.0625"},
+		{"3", "-0.6", "-5"},
+		{"7.5", "0.25", "30"},
+		{"1.00", "1", "1"},
+		{"0", "7", "0"},
+		{"1", "3", ""},
+		{"1", "12", ""},
+		{"123456789012345678901234567890", "0.0000002", "617283945061728394506172839450000000"},
+		{"1e-20", "5e3", "0.000000000000000000000002"},
+	}
+	for _, test := range tests {
+		a, b := RequireFromString(test.a), RequireFromString(test.b)
+		got, err := a.DivExact(b)
+		if test.want == "" {
+			if err != ErrNonTerminating {
+				t.Errorf("expected %v for %s / %s, got %s and %v", ErrNonTerminating, a, b, got, err)
+			}
+			continue
+		}
+		if err != nil || got.String() != test.want {
+			t.Errorf("expected %s for %s / %s, got %s and %v", test.want, a, b, got, err)
+		}
+		if !got.Mul(b).Equal(a) {
+			t.Errorf("%s * %s != %s", got, b, a)
+		}
+	}
+
+	if _, err := New(1, 0).DivExact(Zero); err != ErrDivisionByZero {
+		t.Errorf("expected %v, got %v", ErrDivisionByZero, err)
+	}
+	if _, err := New(1, math.MaxInt32).DivExact(New(1, math.MinInt32)); err != ErrExponentOverflow {
+		t.Errorf("expected %v, got %v", ErrExponentOverflow, err)
+	}
+	if q, err := Inf(1).DivExact(New(-2, 0)); err != nil || !q.IsInf(-1) {
+		t.Errorf("expected -Inf, got %s and %v", q, err)
+	}
+}
+
+func TestDecimal_DivWithStatus(t *testing.T) {
+	tests := []struct {
+		a, b  string
+		prec  int32
+		want  string
+		exact bool
+	}{
+		{"10", "4", 2, "2.5", true},
+		{"10", "4", 0, "3", false},
+		{"10", "3", 2, "3.33", false},
+		{"-2", "3", 3, "-0.667", false},
+		{"1", "1024", 10, "0.0009765625", true},
+		{"1", "1024", 9, "0.000976563", false},
+		{"1", "0", 2, "Inf", false},
+		{"-1", "0", 2, "-Inf", false},
+		{"1", "-0", 2, "-Inf", false},
+		{"0", "0", 2, "NaN", false},
+		{"Inf", "2", 2, "Inf", false},
+		{"-Inf", "2", 2, "-Inf", false},
+		{"2", "Inf", 2, "0", true},
+		{"Inf", "Inf", 2, "NaN", false},
+		{"NaN", "2", 2, "NaN", false},
+		{"2", "NaN", 2, "NaN", false},
+		{"sNaN", "2", 2, "NaN", false},
+	}
+	for _, test := range tests {
+		a, b := RequireFromString(test.a), RequireFromString(test.b)
+		got, exact := a.DivWithStatus(b, test.prec)
+		if got.String() != test.want || exact != test.exact {
+			t.Errorf("expected %s, %t for %s / %s with precision %d, got %s, %t",
+				test.want, test.exact, a, b, test.prec, got, exact)
+		}
+		if want := a.DivRound(b, test.prec); !got.Equal(want) && !want.IsNaN() {
+			t.Errorf("expected the result of DivRound %s, got %s", want, got)
+		}
+	}
+
+	// the scale of the quotient overflows an int32
+	got, exact := New(1, 0).DivWithStatus(New(3, math.MinInt32+10), 20)
+	if !got.IsNaN() || exact {
+		t.Errorf("expected NaN, false for an overflowing scale, got %s, %t", got, exact)
+	}
+}
+
+func TestDecimal_CheckedArithmetic(t *testing.T) {
+	one := New(1, 0)
+
+	if _, err := one.DivE(Zero); err != ErrDivisionByZero {
+		t.Errorf("expected %v, got %v", ErrDivisionByZero, err)
+	}
+	if _, err := Zero.DivE(Zero); err != ErrDivisionByZero {
+		t.Errorf("expected %v, got %v", ErrDivisionByZero, err)
+	}
+	if q, err := New(2, 0).DivE(New(3, 0)); err != nil || q.String() != "0.6666666666666667" {
+		t.Errorf("expected 0.6666666666666667, got %s and %v", q, err)
+	}
+	if _, err := New(1, math.MaxInt32).DivE(New(1, math.MinInt32)); err != ErrExponentOverflow {
+		t.Errorf("expected %v, got %v", ErrExponentOverflow, err)
+	}
+
+	if _, _, err := one.QuoRemE(Zero, 2); err != ErrDivisionByZero {
+		t.Errorf("expected %v, got %v", ErrDivisionByZero, err)
+	}
+	if _, _, err := New(1, math.MaxInt32).QuoRemE(New(1, math.MinInt32), 0); err != ErrExponentOverflow {
+		t.Errorf("expected %v, got %v", ErrExponentOverflow, err)
+	}
+	if q, r, err := New(-75, -1).QuoRemE(New(2, 0), 0); err != nil || q.String() != "-3" || r.String() != "-1.5" {
+		t.Errorf("expected -3 and -1.5, got %s, %s and %v", q, r, err)
+	}
+
+	if _, err := one.ModE(Zero); err != ErrDivisionByZero {
+		t.Errorf("expected %v, got %v", ErrDivisionByZero, err)
+	}
+	if r, err := New(41, 0).ModE(New(21, 0)); err != nil || r.String() != "20" {
+		t.Errorf("expected 20, got %s and %v", r, err)
+	}
+
+	if _, err := one.RoundCashE(231); err != ErrInvalidInterval {
+		t.Errorf("expected %v, got %v", ErrInvalidInterval, err)
+	}
+	if r, err := RequireFromString("3.43").RoundCashE(5); err != nil || r.String() != "3.45" {
+		t.Errorf("expected 3.45, got %s and %v", r, err)
+	}
+
+	if _, err := New(1, math.MaxInt32).ShiftE(1); err != ErrExponentOverflow {
+		t.Errorf("expected %v, got %v", ErrExponentOverflow, err)
+	}
+	if _, err := New(1, math.MinInt32).ShiftE(-1); err != ErrExponentOverflow {
+		t.Errorf("expected %v, got %v", ErrExponentOverflow, err)
+	}
+	if r, err := New(15, -1).ShiftE(2); err != nil || r.String() != "150" {
+		t.Errorf("expected 150, got %s and %v", r, err)
+	}
+	if r, err := Inf(1).ShiftE(math.MaxInt32); err != nil || !r.IsInf(1) {
+		t.Errorf("expected Inf, got %s and %v", r, err)
+	}
+}
+
 func TestDecimal_Overflow(t *testing.T) {
 	if !didPanic(func() { New(1, math.MinInt32).Mul(New(1, math.MinInt32)) }) {
 		t.Fatalf("should have gotten an overflow panic")
@@ -2688,7 +3449,7 @@ func TestDecimal_Pow(t *testing.T) {
 	}{
 		{"0.0", "1.0", "0.0"},
 		{"0.0", "5.7", "0.0"},
-		{"0.0", "-3.2", "0.0"},
+		{"0.0", "-3.2", "Inf"},
 		{"3.13", "0.0", "1.0"},
 		{"-591.5", "0.0", "1.0"},
 		{"3.0", "3.0", "27.0"},
@@ -3648,15 +4409,15 @@ func TestAtan(t *testing.T) {
 		"11000020.2407442310156021090304691671842603586882014729198302312846062338790031898128063403419218957424",
 	}
 	sols := []string{
-		"-1.24076438822058001027437062753106",
-		"-0.78539816339744833061616997868383",
+		"-1.240764388220580068273352361803151442098584699687552910487472296",
+		"-0.785398163397448309615660845819875721049292349843776455243736148",
 		"-0.24497866312686415",
 		"0.0",
 		"0.318747560420644443",
-		"0.78539816339744833061616997868383",
-		"1.37340076694501580123233995736766",
-		"1.47112767430373453123233995736766",
-		"1.57079623588597296123259450235374",
+		"0.785398163397448309615660845819875721049292349843776455243736148",
+		"1.373400766945015859231321691639751442098584699687552910487472296",
+		"1.471127674303734589231321691639751442098584699687552910487472296",
+		"1.570796235885973019231576236625831442098584699687552910487472296",
 	}
 	for i, inp := range inps {
 		d, err := NewFromString(inp)
@@ -3687,15 +4448,15 @@ func TestSin(t *testing.T) {
 		"10",
 		"11000020.2407442310156021090304691671842603586882014729198302312846062338790031898128063403419218957424",
 	}
-	sols := []string{"-0.22057186252002995641471297726318877448242875710373383657841216606788849153474483300147427943530288911869356126149550184271061369789963810497434594683859566879253561990821788142048867910104964466745284318577343435957806286762494529983369776697504436326725441516925396488258485248699247367113416543705253919473126183478178486954138205996912770183192357029798618739277146694040778731661407420114923656224752540889120768",
-		"-0.841470984807896544828551915928318375739843472469519282898610111931110319333748010828751784005573402229699531838022117989945539661588502120624574802425114599802714611508860519655182175315926637327774878594985045816542706701485174683683726979309922117859910272413672784175028365607893544855897795184024100973080880074046886009375162838756876336134083638363801171409953672944184918309063800980214873465660723218405962257950683415203634506166523593278",
+	sols := []string{"-0.22057186252003009357622823754813788425493139393398738658612138454528593805784949101496244377452710835624907341719274472432417724245033314430449267540645201130132175291026371048285178854274930597497856203961929908638906306856497867398556506570276761341902420240916704470031940214086815086888311235522568379281183376369204322187573170394859513173511697471523634616067339483720999662451405505819654021238972446540119917680723155839152672815841665654289950875931277807503561746284115886130537043152710627663894902637731617724661186274359059068330275602881898343043913620574146644466691277115554834927372518330334465548469987004048315304558668650485020250323903330518008729099069499045109549412842957702576208567387703690265207586882037380167793999576660901114866776906092832633892767678337010431934014203383965094967192854692918192121686641210294272",
+		"-0.841470984807896506838544125405336909486961417991173139190949879574861201121497718509651731330426200467685676193583627475014265408023702424248426126053439365524627182275288933295873332002235459131827583499799198545480729632902647402915060499557512055546845003266643603388983730022054463233310316715672101258704762529428759669905691626945240625714859380845612353483655278766474704945898465897441445842931415560089146346445063275356440079939719549420993829964888294888695299108037859779455573023656406479978508548813800843578914176937601395392785241877392425930510875790641816903971162029083991709596873426506792718312545261772849051397621410824230723580130613534287704035205583918856368210833076377309249953704985135237953005139685584652287925405545246805483132566681153673722671153005183817462458038079398155122490826841297715092256796846517744009692287027284281614085866020997460980979839976881017301508292608",
 		"-0.2474039592545229296662577977006816864013671875",
 		"0",
 		"0.3240430283948683457891331120415701894104386268737728",
-		"0.841470984807896544828551915928318375739843472469519282898610111931110319333748010828751784005573402229699531838022117989945539661588502120624574802425114599802714611508860519655182175315926637327774878594985045816542706701485174683683726979309922117859910272413672784175028365607893544855897795184024100973080880074046886009375162838756876336134083638363801171409953672944184918309063800980214873465660723218405962257950683415203634506166523593278",
-		"-0.958924274663138409032065951037351417114444405831206421994322505831797734568720303321152847999323782235893449831846516332891972309733806145798957570823292783131379570446989311599459252931842975162373777189193072018951049969744350662993214861042908755303566670204873618202680865638534865944483058650517380292320436016362659617294570185140789829574277032406195741535138712427510938542219940873171248862329526140744770994303733112530324791184417282382",
-		"-0.54402111088937016772477554483765124109312606762621462357463994520238396180161585438877562935656067241573063207614488370477645194661241525080677431257416988398683714890165970942834453391033857378247849486306346743023618509617104937236345831462093934032592562972419977883837745736210439651143668255744843041350221801750331646628192115694352540293150183983357476391787825596543270240461102629075832777618592034309799936",
-		"-0.564291758480422881634770440632390475980828840253516895637281099241819037882007239070203007530085741820184955492382572029153491807930868879341091067301689987699567034024159005627332722089169680203292567574310010066799858914647295684974242359142300929248173166551428537696685165964880390889406578530338963341989826231514301546476672476399906348023294571001061677668735117509440368611093448917120819545826797975989350435900286332895885871219875665471968941335407351099209738417818747252638912592184093301853338763294381446907254104878969784040526201729163408095795934201105630182851806342356035203279670146684553491616847294749721014579109870396804713831114709372638323643327823671187472335866664108658093206409882794958673673978956925250261545083579947618620746006004554405785185537391110314728988164693223775249484198058394348289545771967707968288542718255197272633789792059019367104377340604030147471453833808674013259696102003732963091159662478879760121731138091114134586544668859915547568540172541576138084166990547345181184322550297604278946942918844039406876827936831612756344331500301118652183156052728447906384772901595431751550607818380262138322673253023464533931883787069611052589166000316238423939491520880451263927981787175602294299295744",
+		"0.841470984807896506838544125405336909486961417991173139190949879574861201121497718509651731330426200467685676193583627475014265408023702424248426126053439365524627182275288933295873332002235459131827583499799198545480729632902647402915060499557512055546845003266643603388983730022054463233310316715672101258704762529428759669905691626945240625714859380845612353483655278766474704945898465897441445842931415560089146346445063275356440079939719549420993829964888294888695299108037859779455573023656406479978508548813800843578914176937601395392785241877392425930510875790641816903971162029083991709596873426506792718312545261772849051397621410824230723580130613534287704035205583918856368210833076377309249953704985135237953005139685584652287925405545246805483132566681153673722671153005183817462458038079398155122490826841297715092256796846517744009692287027284281614085866020997460980979839976881017301508292608",
+		"-0.958924274663138468867061204165318941924376527119885909692030530494795783762504919930454266442126996750621368376748625980772804792461299973136884786363048940690872164877910457089476430475837496170688609796342189682363439212706638571253086873189510440879484558485475633887977868154855173866578469269107275516253703500345396335087042128469206305711868594125240087262519551686799587272443990102874189341528163779109918799960701884090434162534504122735523183283249347705678016881670203246402730320587969703611226678444239093358998337087082619485392890381390686810019884337642388789374323879464818277263341411734626969899813822639227050325457870171394439220539831732609814957280210922532553622590553656452650867597176114410957857486621942472067339286891592705480169356930158780074308489159881319584136875966535796547669338571525677910977791411125650196390602873147442660516584204950736164234382622441577816786993152",
+		"-0.54402111088936981374145642644937792073052181153901372142621369386024316343062384439930096809134979057637552437156113742551297988281289585990412051684277575157028901244059910127650593257905936113789061367993872298173956650883136495125403091118930300211781972063963293970511360137317266350378567011881422288124529039569706587370069578552060101241127020089475001790410911533669372320293182526324521709660066877885939767772826718264006946987929044923105115458177254365836259379833120669261240184670039009722289091604161592482824245575340137023750612575019278006085002447888649980976525296884407697938596312755967511734849625562750749927502500497359562885512269193242037355946092981130405074667341919883237113190879545111605046855517800620769759583846044451943993286332275120346578215562991165925739033306617896620186327422589181976189125680005382144",
+		"-0.564291758073920671403377228368890872319089623129039174310322165468713019734973524539189899770262808548082645163971660010568447367814644296464396962260557575339255306268357085939810179444440627318173224832810539989969899749201217527244406703704103127481864841003006736954989258542238620793033948998615145840083923839748415889376783404907132244717239856259004028931849943097768456948319304120998947738696506011892028045050008776545583558646262175356066640969577481869652158437912645683298880187185244688190732934157107622429397962553271330343960413539638057869475912497223531226055610416136730411250507724385308164255959202122715374249332999796180504710359005934650401046074237305929047069176810989403937382708828444327141877434352307030522522788058616253490993187326911315054819474200269114792340764736627393507704826822832826527245775133520632833439218439218950029324058052810322148420849799240127566782983100091456760649940928411582724489367928317264286680165716174229999141072352552101785939127956068730212937292824431471453815450337644458774395016896745608439585960719693925388746243741246626712060927621326001134157290374827026480482323341698075559894256006759803198826680365312148206677914082166427903017941770051263927981787175602294299295744",
 	}
 	for i, inp := range inps {
 		d, err := NewFromString(inp)
@@ -3727,15 +4488,15 @@ func TestCos(t *testing.T) {
 		"11000020.2407442310156021090304691671842603586882014729198302312846062338790031898128063403419218957424",
 	}
 	sols := []string{
-		"-0.975370726167463467746508538219884948528729295145689640359666742268127382748782064668565276308334226452812521220478854320025773591423493734486361306323829818426063430805234608660356853863442937297855742231573288105774823103008774355455799906250461848079705023428527473474556899228935370709945979509634251305018978306493011197513482210179171510947538040406781879762352211326273272515279567525396877609653501706919545667682725671944948392322552266752",
-		"0.54030230586813965874561515067176071767603141150991567490927772778673118786033739102174242337864109186439207498973007363884202112942385976796862442063752663646870430360736682397798633852405003167527051283327366631405990604840629657123985368031838052877290142895506386796217551784101265975360960112885444847880134909594560331781699767647860744559228420471946006511861233129745921297270844542687374552066388998112901504",
+		"-0.975370726167463436728588812550155925360003755522685077691618587756113665535758997898144942973566900840456480729452157350311516132535718245170258487054855187839117376934847437171814531160571770951813857277703344200950844188052334875544102214427430160318455072155429509889349636172486578556187644794680975100360267553909020088597793403946457604203076653611486029657772780717284298356263461176172640894285692644589980494176083861610295351905992937222085462832652832360342357461889989888086965347573856756475458618452351034937468708800350812872078905944256570062087660126429081843029927017177099022586698276907269015784190737735439363325136379117320643721506823725793734981580861022966740407977649341218732852308324604343298498975815603284083335745884246556718962635253383421075121718774876351230190755689468032328003192272557688202560373076922174514196902933000586622828888022400712373698181218587467911866089472",
+		"0.54030230586813971791154674333216891147240693514634057931113157190689343643981298556400119756090892920116244243143218043966835609974325796487057172753398977353533962865606425039615643258919634431044735566069993188887979917290228224106775125564921672941520159277053424427462079901370160530176678917911077468052257397545784766249312030780588238963919841712797916537825806565287073183765569729175029833155505000028400125382278475878678236239353578491904054297810441491378693990148585080493069322787214262791969526283756679572320072258665273267650023576838673519242910800354217767260730391610835602041241583772205625082142955397025608454421210134758544922157621912401812842458926516957453979548762434348349697315663108412150405537692479681742637994625921250027896432205524824424161466915397961969145088505093186519176631737409020131615249229326319616",
 		"0.968912421710644784099084544806854121387004852294921875",
 		"1",
 		"0.9460423435283869715490383692051286742343482760977712222",
-		"0.54030230586813965874561515067176071767603141150991567490927772778673118786033739102174242337864109186439207498973007363884202112942385976796862442063752663646870430360736682397798633852405003167527051283327366631405990604840629657123985368031838052877290142895506386796217551784101265975360960112885444847880134909594560331781699767647860744559228420471946006511861233129745921297270844542687374552066388998112901504",
-		"0.28366218546322646623291670213892426815646045792775066552057877370468842342090306560693620285882975471913545189522117672866861003904575909174769890684057564495184019705963607555427518763375472432216131070235796577209064861003009894615394882021220247535890708789312783718414424224334988974848162884228012265684775651099758365989567444515619764427493598258393280941942356912304265535918025036942025858493361644535438208",
-		"-0.839071529076452222947082170022504835457755803801719612447629165523199043803440231769716865070163209041973184176293170330332317060558438085478980463542480791358920580076809381102480339018809694514100495572097422057215638383077242523713704127605770444906854175870243452753002238589530499630034663296166308443155999957196346563161387705205277189957388653461251461388391745795979375660087266037741360406956289962327970672363315696841378765492754546688",
-		"-0.82557544253149396284458404188071504476091346830440347376462206521981928020803354950315062147200396866217255527509254080721982393941347365824137698491042935894213870423296625749297033966815252917361266452901192457318047750698424190124169875103436588397415032138037063155981648677895645409699825582226442363080800781881653440538927704569142007751338851079530521979429507520541625303794665680584709171813053216867014700596866196844144286737568957809383224972108999354839705480223052622003994027222120126949093911643497423100187973906980635670000034664323357488815820848035808846624518774608931622703631130673844138378087837990739103263093532314835289302930152150130664948083902949999427848344301686172490282395687167681679607401220592559832932068966455384902377056623736013617949634746332323529256184776892339963173795176200590119077305668901887229709592836744082027738666294887303249770621722032438202753270710379312736193201366287952361100525126056993039858894987153270630277483613793395809214871734783742285495171911648254647287555645360520115341268930844095156502348405343740866836850201634640011708462641462047870611041595707018966032206807675586825362640000739202116391403514629284000986232673698892843586989003952425039512325844566790376383098534975022847888104706525937115931692008959513984157709954859352131323440787667052399474107219968",
+		"0.54030230586813971791154674333216891147240693514634057931113157190689343643981298556400119756090892920116244243143218043966835609974325796487057172753398977353533962865606425039615643258919634431044735566069993188887979917290228224106775125564921672941520159277053424427462079901370160530176678917911077468052257397545784766249312030780588238963919841712797916537825806565287073183765569729175029833155505000028400125382278475878678236239353578491904054297810441491378693990148585080493069322787214262791969526283756679572320072258665273267650023576838673519242910800354217767260730391610835602041241583772205625082142955397025608454421210134758544922157621912401812842458926516957453979548762434348349697315663108412150405537692479681742637994625921250027896432205524824424161466915397961969145088505093186519176631737409020131615249229326319616",
+		"0.28366218546322626395981735024669210961923784109051987091061034145363441203168717193242690355729550250704777820221022201766304194510657450578129103065020549063092838072903757540742082774041098721660805328715496859157008839117762302624961558989697460541656582054907949062230816695756990017446797506038100097745120644267006023950650497982737226447788356199825310913867928754303960875896661961260976727247922333139953326893022207078511376163974902058389949153225921689470926611685805510373390823019170313945299575780189622077117302959333189626872893290610060224805277473521005736157340863376237605754719716000432313401482880105625816542751782588432493319535235391313622288946420067044059300803775173756965538572734789287021631475446230200271090058542949956598137694492960482802776681911079466695059294315589186754958518838796702781002952774130860032",
+		"-0.839071529076452452455999860334775743890460256710560474529652763669264568163074884979650852911452328269064995992507142229193094676592869981045898375939580118058045329636148717412605906179589659165521463887466566393669226481951282962244273766231187666512043678639778466602425176504940135404872491449207896231237778702946599030066404655436807052658590099444562525740678718537426223104654018558238742828807466058484350200522430131599768990703628208554245032714241811173744906892036671093292296906332430418342968489324068355068711647511007556518699655194553304755798449116063790726988432777135527161573797146905007234327864716552783652353747406335896309872451090957519662406993347535448492850150213857166414895513537178177314190666847477683934062197562946557855825988000865711162542651495540066498575835722490637014443155876706893460134479882652817663637473647700549903715613912861314816124886082810950238095802368",
+		"-0.82557544280934361182766726321923735893072347646785208427644633935985549979994967521933807488876512973303109856261787793772884321193739621343321091971412841811722790422167857931686071493260047384848462760163176889338355215385208443288281529882014806893325299726643076100845373402115228872101566575000168965839253997201332490224019913491837153304940875889947962125485080264554302619855569495223213002314070338296410073132374183871086557571740197017930493904591536053109326348422817464088155866078010318771657149012338344982610108541679616710115490089050099057902885465032049954184340905485013432866371354784673171468440336058712861729862285640224304843799470968449759855755857479630218858657327299088895390452934057805249301950181339896961598706721084486675119169308040266557257426445738540100977100679324780458152329130271333565488237969460127462383150239363414012404255849582971478607241674983062241680461220053320702023987880490936104606924118964139860805979377159116833571343726367916503242251251143532431895020157944698237991479238589973424208908570240780173109769035244791102117509583631888667341994814008964669945421291523252571551283584114672071300876651441138137791312627709802039740320605008730502375107952974796934769266829228105774697255678347554171635015059738229867079120644688071966873347096042225731323440787667052399474107219968",
 	}
 	for i, inp := range inps {
 		d, err := NewFromString(inp)
@@ -3767,15 +4528,15 @@ func TestTan(t *testing.T) {
 		"11000020.2407442310156021090304691671842603586882014729198302312846062338790031898128063403419218957424",
 	}
 	sols := []string{
-		"0.2261415650505790298980791606748881031998682652",
-		"-1.5574077246549025",
+		"0.226141565050579195128504091376760382285366486668486327337804387677811045229976",
+		"-1.5574077246549022",
 		"-0.255341921221036275",
 		"0.0",
 		"0.342524867530038963",
-		"1.5574077246549025",
-		"-3.3805150062465829",
-		"0.6483608274590872485524085572681343280321117494",
-		"0.68351325561491170753499935023939368502774607234006019034769919811202010905597996164029250820702097041244539696",
+		"1.5574077246549022",
+		"-3.3805150062465857",
+		"0.6483608274590866579920795963461014490300166942285435819366314178603929004164848",
+		"0.68351325489248697193589922095641426985348329320155464910554006431548658819119825293631551943461042025770422",
 	}
 	for i, inp := range inps {
 		d, err := NewFromString(inp)
@@ -0,0 +1,236 @@
+package decimal
+
+import (
+	"math"
+)
+
+// maxExpDigits is the maximum number of integer digits of the results of Exp.
+const maxExpDigits = 100000
+
+// Exp returns e**d, correctly rounded half up to precision digits after the
+// decimal point. Negative precision is allowed.
+//
+// Contrary to ExpHullAbrham and ExpTaylor, Exp picks the algorithm itself: it
+// uses float64 when its precision suffices, and otherwise a Taylor series of a
+// reduced argument. The argument is divided by a power of two 2^k, which grows
+// with its integer part, so that the series converges quickly, and the result
+// of the series squared k times.
+//
+// Exp returns ErrExpTooManyDigits when the result would have more than 100000
+// integer digits, i.e. for d > 230258.5, rather than computing it for minutes,
+// and ErrExponentOverflow when precision is too large to represent the result.
+// The results of large negative arguments are rounded to zero without
+// computation.
+//
+// Example:
+//
+//	d1, err := NewFromFloat(26.1).Exp(2)
+//	d1.String() // output: "216314672147.06"
+//
+//	d2, err := NewFromInt(1).Exp(30)
+//	d2.String() // output: "2.718281828459045235360287471353"
+func (d Decimal) Exp(precision int32) (Decimal, error) {
+	if d.form != formFinite {
+		return expSpecial(d), nil
+	}
+	if d.IsZero() {
+		return New(1, 0).Round(precision), nil
+	}
+
+	// e**d is too large for d >= 10^10, and rounds to zero for d <= -10^10
+	adj := int64(d.exp) + int64(d.NumDigits()) - 1
+	if adj >= 10 {
+		if d.Sign() > 0 {
+			return Decimal{}, ErrExpTooManyDigits
+		}
+		return New(0, 0).Round(precision), nil
+	}
+	if adj < -int64(precision)-5 {
+		// e**d is within 10^-(precision+4) of 1
+		return New(1, 0).Round(precision), nil
+	}
+
+	// mag is the decimal logarithm of the result
+	mag := d.InexactFloat64() * math.Log10E
+	if mag > maxExpDigits {
+		return Decimal{}, ErrExpTooManyDigits
+	}
+	if float64(precision)+mag+16 >= math.MaxInt32 {
+		return Decimal{}, ErrExponentOverflow
+	}
+	if -mag > float64(precision)+2 {
+		// e**d < 10^-(precision+2) rounds to zero
+		return New(0, 0).Round(precision), nil
+	}
+
+	x := d.Abs()
+	one := New(1, 0)
+	return roundApprox(precision, func(places int32) (Decimal, error) {
+		if d.Sign() > 0 {
+			return x.expAbs(places, mag), nil
+		}
+		// e**x >= 1, the error of its reciprocal is less than its own
+		y := x.expAbs(places+2, -mag)
+		return one.DivRound(y, places+2), nil
+	})
+}
+
+// expAbs returns e**d for d > 0 with an error of about a unit in the given
+// number of places, where mag is the decimal logarithm of the result.
+func (d Decimal) expAbs(places int32, mag float64) Decimal {
+	f := d.InexactFloat64()
+	// the relative error of math.Exp and of the conversions to and from
+	// float64 is less than (f + 2) * 2^-52
+	if float64(places)+mag+math.Log10(f+2) <= 15 {
+		return NewFromFloat(math.Exp(f))
+	}
+
+	// e**d = (e**r)^(2^k) with r = d / 2^k < 2^-8, where r is exact as
+	// d / 2^k = d * 5^k / 10^k
+	k := int32(math.Ceil(math.Log2(f+1))) + 8
+	pow5, _ := New(5, 0).PowInt32(k)
+	r := d.Mul(pow5).Shift(-k)
+
+	// squaring multiplies the error of e**r by 2^k, and by the result
+	w := places + int32(math.Ceil(mag+float64(k)*math.Log10(2))) + 3
+	epsilon := New(1, -w-1)
+	sum, term := New(1, 0), New(1, 0)
+	for i := int64(1); ; i++ {
+		term = term.Mul(r).DivRound(New(i, 0), w+2)
+		sum = sum.Add(term)
+		if term.Cmp(epsilon) < 0 {
+			break
+		}
+	}
+	for i := int32(0); i < k; i++ {
+		sum = sum.Mul(sum).Round(w)
+	}
+	return sum
+}
+
+// Sinh returns the hyperbolic sine of d, rounded half up to DivisionPrecision
+// digits after the decimal point, see SinhPrec.
+func (d Decimal) Sinh() (Decimal, error) {
+	return d.SinhPrec(int32(DivisionPrecision))
+}
+
+// SinhPrec returns the hyperbolic sine of d, correctly rounded half up to
+// precision digits after the decimal point. Negative precision is allowed.
+// SinhPrec(±Inf) is ±Inf.
+//
+// SinhPrec returns ErrExpTooManyDigits when the result would have more than
+// 100000 integer digits, like Exp.
+//
+// Example:
+//
+//	d, err := NewFromInt(1).SinhPrec(20)
+//	d.String() // output: "1.17520119364380145688"
+func (d Decimal) SinhPrec(precision int32) (Decimal, error) {
+	if d.form != formFinite {
+		return d, nil
+	}
+	if d.IsZero() {
+		return New(0, 0).Round(precision), nil
+	}
+	return roundApprox(precision, func(places int32) (Decimal, error) {
+		a, b, err := d.expPair(places)
+		if err != nil {
+			return Decimal{}, err
+		}
+		r := a.Sub(b).Mul(New(5, -1))
+		if d.Signbit() {
+			r = r.Neg()
+		}
+		return r, nil
+	})
+}
+
+// Cosh returns the hyperbolic cosine of d, rounded half up to
+// DivisionPrecision digits after the decimal point, see CoshPrec.
+func (d Decimal) Cosh() (Decimal, error) {
+	return d.CoshPrec(int32(DivisionPrecision))
+}
+
+// CoshPrec returns the hyperbolic cosine of d, correctly rounded half up to
+// precision digits after the decimal point. Negative precision is allowed.
+// CoshPrec(±Inf) is Inf.
+//
+// CoshPrec returns ErrExpTooManyDigits when the result would have more than
+// 100000 integer digits, like Exp.
+//
+// Example:
+//
+//	d, err := NewFromInt(1).CoshPrec(20)
+//	d.String() // output: "1.54308063481524377848"
+func (d Decimal) CoshPrec(precision int32) (Decimal, error) {
+	if d.form != formFinite {
+		return d.Abs(), nil
+	}
+	return roundApprox(precision, func(places int32) (Decimal, error) {
+		a, b, err := d.expPair(places)
+		if err != nil {
+			return Decimal{}, err
+		}
+		return a.Add(b).Mul(New(5, -1)), nil
+	})
+}
+
+// Tanh returns the hyperbolic tangent of d, rounded half up to
+// DivisionPrecision digits after the decimal point, see TanhPrec.
+func (d Decimal) Tanh() (Decimal, error) {
+	return d.TanhPrec(int32(DivisionPrecision))
+}
+
+// TanhPrec returns the hyperbolic tangent of d, correctly rounded half up to
+// precision digits after the decimal point. Negative precision is allowed.
+// TanhPrec(±Inf) is ±1, and so are the results of large arguments, which are
+// rounded without computation.
+//
+// Example:
+//
+//	d, err := NewFromInt(1).TanhPrec(20)
+//	d.String() // output: "0.76159415595576488812"
+func (d Decimal) TanhPrec(precision int32) (Decimal, error) {
+	if d.IsNaN() {
+		return d, nil
+	}
+	one := New(1, 0)
+	if d.Signbit() {
+		one = New(-1, 0)
+	}
+	if d.form != formFinite {
+		return one, nil
+	}
+	if d.IsZero() {
+		return New(0, 0).Round(precision), nil
+	}
+
+	// 1 - tanh(x) < 2 * e**-2x is below 10^-(precision+2) for
+	// x > (precision + 3) * ln(10) / 2
+	adj := int64(d.exp) + int64(d.NumDigits()) - 1
+	if adj >= 10 || d.Abs().InexactFloat64() > (float64(precision)+3)*math.Ln10/2 {
+		return one.Round(precision), nil
+	}
+
+	return roundApprox(precision, func(places int32) (Decimal, error) {
+		a, b, err := d.expPair(places)
+		if err != nil {
+			return Decimal{}, err
+		}
+		r := a.Sub(b).DivRound(a.Add(b), places+1)
+		if d.Signbit() {
+			r = r.Neg()
+		}
+		return r, nil
+	})
+}
+
+// expPair returns e**|d| and e**-|d| with an error of less than a unit in the
+// given number of places.
+func (d Decimal) expPair(places int32) (Decimal, Decimal, error) {
+	a, err := d.Abs().Exp(places + 2)
+	if err != nil {
+		return Decimal{}, Decimal{}, err
+	}
+	return a, New(1, 0).DivRound(a, places+2), nil
+}
@@ -0,0 +1,145 @@
+package decimal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDecimal_Exp(t *testing.T) {
+	tests := []struct {
+		input     string
+		precision int32
+		want      string
+	}{
+		{"26.1", 2, "216314672147.06"},
+		{"1", 30, "2.718281828459045235360287471353"},
+		{"1", 100, "2.7182818284590452353602874713526624977572470936999595749669676277240766303535475945713821785251664274"},
+		{"-1", 30, "0.367879441171442321595523770161"},
+		{"0.5", 5, "1.64872"},
+		{"-0.5", 5, "0.60653"},
+		{"-3.3", 2, "0.04"},
+		{"100", 10, "26881171418161354484126255515800135873611118.7737419224"},
+		{"-100", 50, "0.00000000000000000000000000000000000000000003720076"},
+		{"-100", 40, "0"},
+		{"-20", 8, "0"},
+		{"1e-10", 25, "1.000000000100000000005"},
+		{"-1e-10", 25, "0.999999999900000000005"},
+		{"2.302585092994045684", 10, "10"},
+		{"0.69314718055994530941723212145818", 20, "2"},
+		{"12.345", -2, "229800"},
+		{"0", 3, "1"},
+		{"1000", 5, "197007111401704699388887935224332312531693798532384578995280299138506385078244119347497807656302688993096381798752022693598298173054461289923262783660152825232320535169584566756192271567602788071422466826314006855168508653497941660316045367817938092905299728580132869945856470286534375900456564355589156220422320260518826112288638358372248724725214506150418881937494100871264232248436315760560377439930623959705844189509050047074217568.22676"},
+	}
+	for _, test := range tests {
+		d := RequireFromString(test.input)
+		got, err := d.Exp(test.precision)
+		if err != nil || got.String() != test.want {
+			t.Errorf("expected exp(%s) = %s with precision %d, got %s and %v", d, test.want, test.precision, got, err)
+			continue
+		}
+		if got.Exponent() != -test.precision {
+			t.Errorf("expected exponent %d for exp(%s), got %d", -test.precision, d, got.Exponent())
+		}
+	}
+}
+
+func TestDecimal_ExpSpecial(t *testing.T) {
+	if _, err := New(1, 10).Exp(2); err != ErrExpTooManyDigits {
+		t.Errorf("expected %v, got %v", ErrExpTooManyDigits, err)
+	}
+	if _, err := New(1, math.MaxInt32).Exp(2); err != ErrExpTooManyDigits {
+		t.Errorf("expected %v, got %v", ErrExpTooManyDigits, err)
+	}
+	if _, err := New(123, math.MaxInt32).Exp(2); err != ErrExpTooManyDigits {
+		t.Errorf("expected %v, got %v", ErrExpTooManyDigits, err)
+	}
+	if _, err := New(10, 0).Exp(math.MaxInt32 - 10); err != ErrExponentOverflow {
+		t.Errorf("expected %v, got %v", ErrExponentOverflow, err)
+	}
+	if r, err := New(-1, math.MaxInt32).Exp(2); err != nil || !r.IsZero() {
+		t.Errorf("expected 0, got %s and %v", r, err)
+	}
+	if r, err := New(1, math.MinInt32).Exp(2); err != nil || r.String() != "1" {
+		t.Errorf("expected 1, got %s and %v", r, err)
+	}
+	if r, err := New(-1, -9).Exp(6); err != nil || r.StringFixed(6) != "1.000000" {
+		t.Errorf("expected 1.000000, got %s and %v", r, err)
+	}
+	if r, err := Inf(1).Exp(2); err != nil || !r.IsInf(1) {
+		t.Errorf("expected Inf, got %s and %v", r, err)
+	}
+	if r, err := Inf(-1).Exp(2); err != nil || !r.IsZero() {
+		t.Errorf("expected 0, got %s and %v", r, err)
+	}
+	if r, err := NaN().Exp(2); err != nil || !r.IsNaN() {
+		t.Errorf("expected NaN, got %s and %v", r, err)
+	}
+}
+
+func TestDecimal_Hyperbolic(t *testing.T) {
+	fns := map[string]func(Decimal, int32) (Decimal, error){
+		"sinh": Decimal.SinhPrec,
+		"cosh": Decimal.CoshPrec,
+		"tanh": Decimal.TanhPrec,
+	}
+	tests := []struct {
+		fn, input string
+		precision int32
+		want      string
+	}{
+		{"sinh", "1", 20, "1.17520119364380145688"},
+		{"sinh", "-2.5", 25, "-6.0502044810397873214503236"},
+		{"sinh", "1e-15", 30, "0.000000000000001000000000000000"},
+		{"sinh", "100", 5, "13440585709080677242063127757900067936805559.38687"},
+		{"sinh", "0.5", 0, "1"},
+		{"cosh", "1", 20, "1.54308063481524377848"},
+		{"cosh", "-2.5", 25, "6.1322894796636861166198523"},
+		{"cosh", "1e-15", 30, "1.000000000000000000000000000001"},
+		{"cosh", "30", 3, "5343237290762.231"},
+		{"tanh", "1", 20, "0.76159415595576488812"},
+		{"tanh", "-0.25", 30, "-0.244918662403709129277801131491"},
+		{"tanh", "20", 15, "1.000000000000000"},
+		{"tanh", "1e-10", 25, "0.0000000001000000000000000"},
+		{"tanh", "-40", 20, "-1.00000000000000000000"},
+	}
+	for _, test := range tests {
+		d := RequireFromString(test.input)
+		got, err := fns[test.fn](d, test.precision)
+		if err != nil || !got.Equal(RequireFromString(test.want)) {
+			t.Errorf("expected %s(%s) = %s, got %s and %v", test.fn, d, test.want, got, err)
+		}
+	}
+
+	if r, err := Inf(-1).Sinh(); err != nil || !r.IsInf(-1) {
+		t.Errorf("expected -Inf, got %s and %v", r, err)
+	}
+	if r, err := Inf(-1).Cosh(); err != nil || !r.IsInf(1) {
+		t.Errorf("expected Inf, got %s and %v", r, err)
+	}
+	if r, err := Inf(-1).Tanh(); err != nil || r.String() != "-1" {
+		t.Errorf("expected -1, got %s and %v", r, err)
+	}
+	if r, err := New(1, 100).Tanh(); err != nil || r.String() != "1" {
+		t.Errorf("expected 1, got %s and %v", r, err)
+	}
+	if _, err := New(-1, 20).Cosh(); err != ErrExpTooManyDigits {
+		t.Errorf("expected ErrExpTooManyDigits, got %v", err)
+	}
+
+	// results with millions of digits would take minutes
+	for _, d := range []string{"230259", "1e6", "3e6", "9999999999"} {
+		if _, err := RequireFromString(d).Exp(2); err != ErrExpTooManyDigits {
+			t.Errorf("expected ErrExpTooManyDigits for exp(%s), got %v", d, err)
+		}
+	}
+	d := RequireFromString("123456789.123456789")
+	if _, err := d.Sinh(); err != ErrExpTooManyDigits {
+		t.Errorf("expected ErrExpTooManyDigits for sinh(%s), got %v", d, err)
+	}
+	if _, err := d.Neg().Cosh(); err != ErrExpTooManyDigits {
+		t.Errorf("expected ErrExpTooManyDigits for cosh(-%s), got %v", d, err)
+	}
+	if r, err := RequireFromString("-3e6").Exp(2); err != nil || !r.IsZero() {
+		t.Errorf("expected 0, got %s and %v", r, err)
+	}
+}
@@ -0,0 +1,380 @@
+// Package finance provides the time value of money functions of spreadsheets,
+// such as PMT and RATE, over decimals.
+//
+// The functions follow the sign convention and the arguments of their
+// spreadsheet counterparts: cash paid out is negative and cash received is
+// positive, and the rate is the interest rate per period. The functions return
+// an error for a rate of -1 or less, or a timing other than End and Begin.
+//
+// Each function is computed with guard digits beyond the requested precision,
+// and its result rounded half up to precision digits after the decimal point
+// only once, so that intermediate roundings don't shift the last cents.
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrNoConvergence is returned by RATE when the Newton iteration doesn't
+// converge within the maximum number of iterations.
+var ErrNoConvergence = errors.New("finance: rate doesn't converge")
+
+// guard is the number of digits computed beyond the requested precision.
+const guard = 30
+
+// Timing is the timing of the payments within their periods, the type
+// argument of the spreadsheet functions.
+type Timing int
+
+const (
+	// End is for payments at the end of the periods, type 0.
+	End Timing = iota
+	// Begin is for payments at the beginning of the periods, type 1.
+	Begin
+)
+
+var (
+	zero = decimal.New(0, 0)
+	one  = decimal.New(1, 0)
+)
+
+// PMT returns the payment per period of a loan or an investment with the
+// present value pv and the future value fv after nper periods at the given
+// rate.
+//
+// Example:
+//
+//	// a loan of 10000 paid back in 10 months at 8% a year
+//	finance.PMT(decimal.RequireFromString("0.08").Div(decimal.NewFromInt(12)), decimal.NewFromInt(10), decimal.NewFromInt(10000), decimal.Zero, finance.End, 2)
+//	// output: "-1037.03"
+func PMT(rate, nper, pv, fv decimal.Decimal, when Timing, precision int32) (decimal.Decimal, error) {
+	p, err := payment(rate, nper, pv, fv, when, precision+guard)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return p.Round(precision), nil
+}
+
+// PV returns the present value of nper payments pmt at the given rate,
+// followed by the future value fv.
+func PV(rate, nper, pmt, fv decimal.Decimal, when Timing, precision int32) (decimal.Decimal, error) {
+	if err := checkArgs(rate, when); err != nil {
+		return decimal.Decimal{}, err
+	}
+	w := precision + guard
+	if rate.IsZero() {
+		return fv.Add(pmt.Mul(nper)).Neg().Round(precision), nil
+	}
+	// PV = -pmt * (1 + rate * when) / rate - (fv - pmt * (1 + rate * when) / rate) / (1 + rate)^nper
+	perpetuity := pmt.Mul(factor(rate, when)).DivRound(rate, w)
+	if negligible(rate, nper, fv.Sub(perpetuity).Abs().InexactFloat64(), w) {
+		return perpetuity.Neg().Round(precision), nil
+	}
+	g, err := growth(rate, nper, w)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	annuity := pmt.Mul(factor(rate, when)).Mul(g).DivRound(rate, w)
+	return fv.Add(annuity).Neg().DivRound(g.Add(one), precision), nil
+}
+
+// FV returns the future value after nper periods at the given rate of the
+// present value pv and the payments pmt.
+//
+// Example:
+//
+//	// saving 200 a month for 10 months at 6% a year, on top of 500
+//	finance.FV(decimal.RequireFromString("0.005"), decimal.NewFromInt(10), decimal.NewFromInt(-200), decimal.NewFromInt(-500), finance.Begin, 2)
+//	// output: "2581.4"
+func FV(rate, nper, pmt, pv decimal.Decimal, when Timing, precision int32) (decimal.Decimal, error) {
+	f, err := futureValue(rate, nper, pmt, pv, when, precision+guard)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return f.Round(precision), nil
+}
+
+// NPER returns the number of periods for the present value pv to reach the
+// future value fv with the payments pmt at the given rate. The result is
+// usually fractional. NPER returns an error if fv can't be reached.
+func NPER(rate, pmt, pv, fv decimal.Decimal, when Timing, precision int32) (decimal.Decimal, error) {
+	if err := checkArgs(rate, when); err != nil {
+		return decimal.Decimal{}, err
+	}
+	w := precision + guard
+	if rate.IsZero() {
+		if pmt.IsZero() {
+			return decimal.Decimal{}, fmt.Errorf("finance: cannot calculate number of periods without rate and payment")
+		}
+		return pv.Add(fv).Neg().DivRound(pmt, precision), nil
+	}
+
+	// (1 + rate)^nper = (z - fv) / (z + pv) for z = pmt * (1 + rate * when) / rate
+	z := pmt.Mul(factor(rate, when)).DivRound(rate, w)
+	den := z.Add(pv)
+	if den.IsZero() {
+		return decimal.Decimal{}, fmt.Errorf("finance: cannot calculate number of periods to reach %s", fv)
+	}
+	q := z.Sub(fv).DivRound(den, w)
+	if q.Sign() <= 0 {
+		return decimal.Decimal{}, fmt.Errorf("finance: cannot calculate number of periods to reach %s", fv)
+	}
+	num, err := q.Ln(w)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	lr, err := rate.Log1p(w)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return num.DivRound(lr, precision), nil
+}
+
+// Newton configures the Newton iteration of RATE. Its zero value uses the
+// default of each field.
+type Newton struct {
+	// Guess is the rate the iteration starts from, 0.1 if zero.
+	Guess decimal.Decimal
+	// Tolerance is the change of the rate between two iterations below which
+	// the iteration stops, 10^-(precision+2) if zero.
+	Tolerance decimal.Decimal
+	// MaxIterations is the maximum number of iterations, 100 if zero.
+	MaxIterations int
+}
+
+// RATE returns the interest rate per period for the present value pv to reach
+// the future value fv with nper payments pmt. It's found by Newton's method as
+// configured by newton, and returns ErrNoConvergence if the iteration doesn't
+// converge. Like the spreadsheet function, RATE may find one of several rates
+// when the cash flows change their sign more than once.
+//
+// A step of the iteration to a rate of -1 or less goes halfway to -1 instead,
+// and isn't taken for convergence. An iteration whose rates grow too large to
+// compute also returns ErrNoConvergence. RATE returns an error if the guess
+// isn't greater than -1, like the other functions for the rate.
+//
+// Example:
+//
+//	// the monthly rate of a loan of 8000 paid back with 48 payments of 200
+//	finance.RATE(decimal.NewFromInt(48), decimal.NewFromInt(-200), decimal.NewFromInt(8000), decimal.Zero, finance.End, 10, finance.Newton{})
+//	// output: "0.0077014725"
+func RATE(nper, pmt, pv, fv decimal.Decimal, when Timing, precision int32, newton Newton) (decimal.Decimal, error) {
+	rate := newton.Guess
+	if rate.IsZero() {
+		rate = decimal.New(1, -1)
+	}
+	tolerance := newton.Tolerance
+	if tolerance.IsZero() {
+		tolerance = decimal.New(1, -precision-2)
+	}
+	iterations := newton.MaxIterations
+	if iterations == 0 {
+		iterations = 100
+	}
+	if err := checkArgs(rate, when); err != nil {
+		return decimal.Decimal{}, err
+	}
+	w := precision + guard
+	minusOne := decimal.New(-1, 0)
+
+	for i := 0; i < iterations; i++ {
+		f, df, err := rateEquation(rate, nper, pmt, pv, fv, when, w)
+		if err != nil || df.IsZero() {
+			return decimal.Decimal{}, ErrNoConvergence
+		}
+		step := f.DivRound(df, w)
+		if rate.Sub(step).Cmp(minusOne) <= 0 {
+			// damp the step to stay above -1, which doesn't converge
+			rate = rate.Sub(rate.Sub(minusOne).DivRound(decimal.New(2, 0), w))
+			continue
+		}
+		rate = rate.Sub(step)
+		if step.Abs().Cmp(tolerance) <= 0 {
+			return rate.Round(precision), nil
+		}
+	}
+	return decimal.Decimal{}, ErrNoConvergence
+}
+
+// IPMT returns the interest part of the payment of the period per, counted
+// from 1, of a loan or an investment like that of PMT.
+//
+// Example:
+//
+//	// the interest of the first month of a loan of 8000 over 3 years at 10% a year
+//	finance.IPMT(decimal.NewFromInt(1).Div(decimal.NewFromInt(120)), decimal.NewFromInt(1), decimal.NewFromInt(36), decimal.NewFromInt(8000), decimal.Zero, finance.End, 2)
+//	// output: "-66.67"
+func IPMT(rate, per, nper, pv, fv decimal.Decimal, when Timing, precision int32) (decimal.Decimal, error) {
+	i, _, err := interest(rate, per, nper, pv, fv, when, precision+guard)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return i.Round(precision), nil
+}
+
+// PPMT returns the principal part of the payment of the period per, counted
+// from 1, of a loan or an investment like that of PMT. It's the payment minus
+// its interest part, see IPMT.
+func PPMT(rate, per, nper, pv, fv decimal.Decimal, when Timing, precision int32) (decimal.Decimal, error) {
+	i, p, err := interest(rate, per, nper, pv, fv, when, precision+guard)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return p.Sub(i).Round(precision), nil
+}
+
+// payment returns the payment of PMT to places decimal places.
+func payment(rate, nper, pv, fv decimal.Decimal, when Timing, places int32) (decimal.Decimal, error) {
+	if err := checkArgs(rate, when); err != nil {
+		return decimal.Decimal{}, err
+	}
+	if nper.IsZero() {
+		return decimal.Decimal{}, fmt.Errorf("finance: cannot calculate payment over zero periods")
+	}
+	if rate.IsZero() {
+		return pv.Add(fv).Neg().DivRound(nper, places), nil
+	}
+	// payment = -pv * rate / (1 + rate * when) - (pv + fv) * rate / ((1 + rate * when) * ((1 + rate)^nper - 1))
+	a := factor(rate, when)
+	if negligible(rate, nper, pv.Add(fv).Mul(rate).DivRound(a, places).Abs().InexactFloat64(), places) {
+		return pv.Mul(rate).Neg().DivRound(a, places), nil
+	}
+	g, err := growth(rate, nper, places)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	num := fv.Add(pv.Mul(g.Add(one))).Mul(rate).Neg()
+	return num.DivRound(a.Mul(g), places), nil
+}
+
+// futureValue returns the future value of FV to places decimal places.
+func futureValue(rate, nper, pmt, pv decimal.Decimal, when Timing, places int32) (decimal.Decimal, error) {
+	if err := checkArgs(rate, when); err != nil {
+		return decimal.Decimal{}, err
+	}
+	if rate.IsZero() {
+		return pv.Add(pmt.Mul(nper)).Neg(), nil
+	}
+	g, err := growth(rate, nper, places)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	annuity := pmt.Mul(factor(rate, when)).Mul(g).DivRound(rate, places)
+	return pv.Mul(g.Add(one)).Add(annuity).Neg(), nil
+}
+
+// interest returns the interest part of the payment of the period per and the
+// payment to places decimal places. The interest is that of the balance after
+// per - 1 periods, none for the first payment at the beginning of a period.
+func interest(rate, per, nper, pv, fv decimal.Decimal, when Timing, places int32) (decimal.Decimal, decimal.Decimal, error) {
+	if per.LessThan(one) || per.GreaterThan(nper) {
+		return decimal.Decimal{}, decimal.Decimal{}, fmt.Errorf("finance: period %s is outside [1, %s]", per, nper)
+	}
+	p, err := payment(rate, nper, pv, fv, when, places)
+	if err != nil {
+		return decimal.Decimal{}, decimal.Decimal{}, err
+	}
+	if when == Begin && per.Equal(one) {
+		return zero, p, nil
+	}
+	balance, err := futureValue(rate, per.Sub(one), p, pv, when, places)
+	if err != nil {
+		return decimal.Decimal{}, decimal.Decimal{}, err
+	}
+	i := balance.Mul(rate)
+	if when == Begin {
+		i = i.DivRound(one.Add(rate), places)
+	}
+	return i, p, nil
+}
+
+// rateEquation returns the value and the derivative with respect to rate of
+// pv * F + pmt * (1 + rate * when) * (F - 1) / rate + fv for
+// F = (1 + rate)^nper, whose root is the rate of RATE.
+func rateEquation(rate, nper, pmt, pv, fv decimal.Decimal, when Timing, places int32) (f, df decimal.Decimal, err error) {
+	if err := checkArgs(rate, when); err != nil {
+		return decimal.Decimal{}, decimal.Decimal{}, err
+	}
+	t := decimal.New(int64(when), 0)
+	if rate.IsZero() {
+		// the limits of f and df at rate zero
+		f = pv.Add(pmt.Mul(nper)).Add(fv)
+		half := decimal.New(5, -1)
+		df = pv.Mul(nper).Add(pmt.Mul(nper.Mul(nper.Sub(one)).Mul(half).Add(t.Mul(nper))))
+		return f, df, nil
+	}
+	g, err := growth(rate, nper, places)
+	if err != nil {
+		return decimal.Decimal{}, decimal.Decimal{}, err
+	}
+	F := g.Add(one)
+	a := factor(rate, when)
+	// dF = nper * F / (1 + rate)
+	dF := nper.Mul(F).DivRound(one.Add(rate), places)
+	gr := g.DivRound(rate, places)
+
+	f = pv.Mul(F).Add(pmt.Mul(a).Mul(gr)).Add(fv)
+	// d/drate (a * g / rate) = when * g / rate + a * (dF - g / rate) / rate
+	dAnnuity := t.Mul(gr).Add(a.Mul(dF.Sub(gr)).DivRound(rate, places))
+	df = pv.Mul(dF).Add(pmt.Mul(dAnnuity))
+	return f, df, nil
+}
+
+// growth returns (1 + rate)^nper - 1 to places decimal places, as
+// e^(nper * ln(1 + rate)) - 1 so that no digits are lost for small rates.
+func growth(rate, nper decimal.Decimal, places int32) (decimal.Decimal, error) {
+	if nper.IsZero() {
+		return zero, nil
+	}
+	// the error of the logarithm is multiplied by nper
+	lp, err := rate.Log1p(places + int32(nper.Abs().Ceil().NumDigits()) + 2)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	x := lp.Mul(nper)
+	if x.Abs().GreaterThan(one) {
+		e, err := x.Exp(places)
+		if err != nil {
+			return decimal.Decimal{}, err
+		}
+		return e.Sub(one), nil
+	}
+	return x.Expm1(places)
+}
+
+// negligible returns whether v / ((1 + rate)^nper - 1) is below 10^-places, so
+// that the terms of a result divided by the growth can be dropped rather than
+// computing a huge power. It's estimated with floats and a margin of two
+// digits, and holds for v = 0.
+func negligible(rate, nper decimal.Decimal, v float64, places int32) bool {
+	if v == 0 {
+		return true
+	}
+	x := nper.InexactFloat64() * math.Log1p(rate.InexactFloat64())
+	return x*math.Log10E > float64(places)+math.Log10(v)+2
+}
+
+// factor returns 1 + rate * when, by which the payments at the beginning of
+// the periods grow more than at their end.
+func factor(rate decimal.Decimal, when Timing) decimal.Decimal {
+	if when == Begin {
+		return one.Add(rate)
+	}
+	return one
+}
+
+// checkArgs returns an error if the rate isn't greater than -1, or the timing
+// neither End nor Begin.
+func checkArgs(rate decimal.Decimal, when Timing) error {
+	if !rate.IsFinite() || rate.Cmp(decimal.New(-1, 0)) <= 0 {
+		return fmt.Errorf("finance: rate %s must be greater than -1", rate)
+	}
+	if when != End && when != Begin {
+		return fmt.Errorf("finance: timing %d must be End or Begin", int(when))
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package finance
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func d(s string) decimal.Decimal {
+	return decimal.RequireFromString(s)
+}
+
+// monthly returns the monthly rate of a yearly rate, like =rate/12 in a
+// spreadsheet.
+func monthly(yearly string) decimal.Decimal {
+	return d(yearly).Div(decimal.NewFromInt(12))
+}
+
+func check(t *testing.T, name string, got decimal.Decimal, err error, want string) {
+	t.Helper()
+	if err != nil {
+		t.Errorf("%s: unexpected error %v", name, err)
+		return
+	}
+	if !got.Equal(d(want)) {
+		t.Errorf("%s: expected %s, got %s", name, want, got)
+	}
+}
+
+// The expected values of the tests are the published examples of the
+// spreadsheet functions, or computed with 60 significant digits.
+
+func TestPMT(t *testing.T) {
+	got, err := PMT(monthly("0.08"), d("10"), d("10000"), d("0"), End, 2)
+	check(t, "PMT(8%/12, 10, 10000)", got, err, "-1037.03")
+	got, err = PMT(monthly("0.08"), d("10"), d("10000"), d("0"), Begin, 2)
+	check(t, "PMT(8%/12, 10, 10000, 0, 1)", got, err, "-1030.16")
+	got, err = PMT(monthly("0.06"), d("216"), d("0"), d("50000"), End, 2)
+	check(t, "PMT(6%/12, 18*12, 0, 50000)", got, err, "-129.08")
+	got, err = PMT(d("0.000001"), d("360"), d("300000"), d("0"), End, 10)
+	check(t, "PMT(0.000001, 360, 300000)", got, err, "-833.4837589999")
+	got, err = PMT(d("0.05"), d("2.5"), d("1000"), d("0"), End, 6)
+	check(t, "PMT(5%, 2.5, 1000)", got, err, "-435.426791")
+	got, err = PMT(d("0"), d("12"), d("1000"), d("-100"), End, 2)
+	check(t, "PMT(0, 12, 1000, -100)", got, err, "-75")
+	got, err = PMT(d("0.05"), d("5e6"), d("1000"), d("0"), End, 2)
+	check(t, "PMT(5%, 5e6, 1000)", got, err, "-50")
+	got, err = PMT(d("0.05"), d("5e6"), d("1000"), d("-300"), Begin, 10)
+	check(t, "PMT(5%, 5e6, 1000, -300, 1)", got, err, "-47.6190476190")
+	got, err = PMT(d("0.05"), d("600"), d("1000"), d("-1000"), End, 6)
+	check(t, "PMT(5%, 600, 1000, -1000)", got, err, "-50")
+
+	if _, err := PMT(d("0.01"), d("0"), d("1000"), d("0"), End, 2); err == nil {
+		t.Errorf("expected error for zero periods")
+	}
+	if _, err := PMT(d("-1"), d("12"), d("1000"), d("0"), End, 2); err == nil {
+		t.Errorf("expected error for rate -1")
+	}
+	for _, when := range []Timing{-1, 2, 5} {
+		if _, err := PMT(d("0.01"), d("12"), d("1000"), d("0"), when, 2); err == nil {
+			t.Errorf("expected error for timing %d", when)
+		}
+	}
+}
+
+func TestPV(t *testing.T) {
+	got, err := PV(monthly("0.08"), d("240"), d("500"), d("0"), End, 2)
+	check(t, "PV(8%/12, 12*20, 500)", got, err, "-59777.15")
+	got, err = PV(d("0"), d("10"), d("-100"), d("-50"), Begin, 2)
+	check(t, "PV(0, 10, -100, -50, 1)", got, err, "1050")
+	got, err = PV(d("0.05"), d("5e6"), d("-50"), d("0"), End, 2)
+	check(t, "PV(5%, 5e6, -50)", got, err, "1000")
+	got, err = PV(d("0.05"), d("5e6"), d("-50"), d("1e9"), Begin, 6)
+	check(t, "PV(5%, 5e6, -50, 1e9, 1)", got, err, "1050")
+}
+
+func TestFV(t *testing.T) {
+	got, err := FV(monthly("0.06"), d("10"), d("-200"), d("-500"), Begin, 2)
+	check(t, "FV(6%/12, 10, -200, -500, 1)", got, err, "2581.40")
+	got, err = FV(monthly("0.12"), d("12"), d("-1000"), d("0"), End, 2)
+	check(t, "FV(12%/12, 12, -1000)", got, err, "12682.50")
+	got, err = FV(monthly("0.11"), d("35"), d("-2000"), d("0"), Begin, 2)
+	check(t, "FV(11%/12, 35, -2000, 0, 1)", got, err, "82846.25")
+	got, err = FV(d("0.5"), d("100"), d("0"), d("-1"), End, 3)
+	check(t, "FV(50%, 100, 0, -1)", got, err, "406561177535215237.397")
+	got, err = FV(d("0.1"), d("2"), d("0"), d("-100"), End, 20)
+	check(t, "FV(10%, 2, 0, -100)", got, err, "121")
+	if _, err := FV(d("0.05"), d("5e6"), d("-50"), d("0"), End, 2); err != decimal.ErrExpTooManyDigits {
+		t.Errorf("expected ErrExpTooManyDigits, got %v", err)
+	}
+}
+
+func TestNPER(t *testing.T) {
+	got, err := NPER(monthly("0.12"), d("-100"), d("-1000"), d("10000"), Begin, 7)
+	check(t, "NPER(12%/12, -100, -1000, 10000, 1)", got, err, "59.6738657")
+	got, err = NPER(monthly("0.12"), d("-100"), d("-1000"), d("10000"), End, 7)
+	check(t, "NPER(12%/12, -100, -1000, 10000)", got, err, "60.0821229")
+	got, err = NPER(monthly("0.12"), d("-100"), d("-1000"), d("0"), End, 8)
+	check(t, "NPER(12%/12, -100, -1000)", got, err, "-9.57859404")
+	got, err = NPER(d("0"), d("-100"), d("1000"), d("0"), End, 2)
+	check(t, "NPER(0, -100, 1000)", got, err, "10")
+
+	if _, err := NPER(d("0.1"), d("-10"), d("1000"), d("0"), End, 2); err == nil {
+		t.Errorf("expected error for a loan whose interest exceeds the payments")
+	}
+	if _, err := NPER(d("0"), d("0"), d("1000"), d("0"), End, 2); err == nil {
+		t.Errorf("expected error without rate and payment")
+	}
+}
+
+func TestRATE(t *testing.T) {
+	got, err := RATE(d("48"), d("-200"), d("8000"), d("0"), End, 10, Newton{})
+	check(t, "RATE(4*12, -200, 8000)", got, err, "0.0077014725")
+	got, err = RATE(d("60"), d("-250"), d("10000"), d("0"), Begin, 12, Newton{})
+	check(t, "RATE(60, -250, 10000, 0, 1)", got, err, "0.014970641304")
+	got, err = RATE(d("10"), d("0"), d("-100"), d("200"), End, 12, Newton{Guess: d("0.05")})
+	check(t, "RATE(10, 0, -100, 200)", got, err, "0.071773462536")
+	got, err = RATE(d("12"), d("-100"), d("1200"), d("0"), End, 10, Newton{})
+	check(t, "RATE(12, -100, 1200)", got, err, "0")
+
+	_, err = RATE(d("48"), d("-200"), d("8000"), d("0"), End, 10, Newton{MaxIterations: 1})
+	if err != ErrNoConvergence {
+		t.Errorf("expected ErrNoConvergence after one iteration, got %v", err)
+	}
+	// the steps from these guesses overshoot far above the rate or below -1
+	for _, guess := range []string{"-0.99", "-0.9"} {
+		got, err = RATE(d("10"), d("0"), d("-100"), d("200"), End, 6, Newton{Guess: d(guess)})
+		if err != ErrNoConvergence {
+			t.Errorf("expected ErrNoConvergence from %s, got %s, %v", guess, got, err)
+		}
+	}
+	got, err = RATE(d("48"), d("-200"), d("8000"), d("0"), End, 6, Newton{Guess: d("-0.5")})
+	if err != ErrNoConvergence {
+		t.Errorf("expected ErrNoConvergence from -0.5, got %s, %v", got, err)
+	}
+	if _, err := RATE(d("10"), d("0"), d("-100"), d("200"), End, 6, Newton{Guess: d("-1")}); err == nil || err == ErrNoConvergence {
+		t.Errorf("expected error for guess -1, got %v", err)
+	}
+	if _, err := RATE(d("10"), d("0"), d("-100"), d("200"), Timing(5), 6, Newton{}); err == nil || err == ErrNoConvergence {
+		t.Errorf("expected error for timing 5, got %v", err)
+	}
+	got, err = RATE(d("48"), d("-200"), d("8000"), d("0"), End, 10, Newton{Tolerance: d("0.01")})
+	if err != nil || got.Sub(d("0.0077014725")).Abs().GreaterThan(d("0.01")) {
+		t.Errorf("expected a rate close to 0.0077 with a coarse tolerance, got %s, %v", got, err)
+	}
+}
+
+func TestIPMTAndPPMT(t *testing.T) {
+	got, err := IPMT(monthly("0.1"), d("1"), d("36"), d("8000"), d("0"), End, 2)
+	check(t, "IPMT(10%/12, 1, 3*12, 8000)", got, err, "-66.67")
+	got, err = IPMT(d("0.1"), d("3"), d("3"), d("8000"), d("0"), End, 2)
+	check(t, "IPMT(10%, 3, 3, 8000)", got, err, "-292.45")
+	got, err = IPMT(d("0.1"), d("3"), d("3"), d("8000"), d("0"), Begin, 2)
+	check(t, "IPMT(10%, 3, 3, 8000, 0, 1)", got, err, "-265.86")
+	got, err = IPMT(d("0.1"), d("1"), d("3"), d("8000"), d("0"), Begin, 2)
+	check(t, "IPMT(10%, 1, 3, 8000, 0, 1)", got, err, "0")
+
+	got, err = PPMT(monthly("0.1"), d("1"), d("24"), d("2000"), d("0"), End, 2)
+	check(t, "PPMT(10%/12, 1, 2*12, 2000)", got, err, "-75.62")
+	got, err = PPMT(d("0.08"), d("10"), d("10"), d("200000"), d("0"), End, 2)
+	check(t, "PPMT(8%, 10, 10, 200000)", got, err, "-27598.05")
+	got, err = PPMT(d("0.1"), d("1"), d("3"), d("8000"), d("0"), Begin, 2)
+	check(t, "PPMT(10%, 1, 3, 8000, 0, 1)", got, err, "-2924.47")
+
+	// the interest and principal parts sum up to the payment
+	rate := monthly("0.045")
+	pmt, _ := PMT(rate, d("360"), d("250000"), d("0"), End, 20)
+	for _, per := range []string{"1", "180", "360"} {
+		i, _ := IPMT(rate, d(per), d("360"), d("250000"), d("0"), End, 20)
+		p, _ := PPMT(rate, d(per), d("360"), d("250000"), d("0"), End, 20)
+		if diff := i.Add(p).Sub(pmt).Abs(); diff.GreaterThan(d("1e-19")) {
+			t.Errorf("expected IPMT + PPMT = PMT for period %s, got %s + %s = %s", per, i, p, pmt)
+		}
+	}
+
+	if _, err := IPMT(d("0.1"), d("1"), d("3"), d("8000"), d("0"), Timing(2), 2); err == nil {
+		t.Errorf("expected error for timing 2")
+	}
+	if _, err := FV(d("0.1"), d("3"), d("-100"), d("0"), Timing(2), 2); err == nil {
+		t.Errorf("expected error for timing 2")
+	}
+	if _, err := NPER(d("0.1"), d("-100"), d("100"), d("0"), Timing(2), 2); err == nil {
+		t.Errorf("expected error for timing 2")
+	}
+	if _, err := PV(d("0.1"), d("3"), d("-100"), d("0"), Timing(2), 2); err == nil {
+		t.Errorf("expected error for timing 2")
+	}
+
+	for _, per := range []string{"0", "4"} {
+		if _, err := IPMT(d("0.1"), d(per), d("3"), d("8000"), d("0"), End, 2); err == nil {
+			t.Errorf("expected error for period %s", per)
+		}
+	}
+}
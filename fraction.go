@@ -0,0 +1,125 @@
+package decimal
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ContinuedFraction returns the terms [a0; a1, a2, ...] of the continued
+// fraction of d, at most maxTerms of them, or all of them if maxTerms < 1.
+// The continued fraction of a decimal is finite. Its first term is the floor
+// of d, the others are positive, and the last one is greater than 1 unless it's
+// the first. NaN and infinities have no continued fraction, ContinuedFraction
+// returns nil for them.
+//
+// Example:
+//
+//	NewFromFloat(3.245).ContinuedFraction(0) // output: [3 4 12 4]
+//	NewFromFloat(-0.5).ContinuedFraction(0)  // output: [-1 2]
+func (d Decimal) ContinuedFraction(maxTerms int) []*big.Int {
+	if d.form != formFinite {
+		return nil
+	}
+	r := d.Rat()
+	p, q := new(big.Int).Set(r.Num()), new(big.Int).Set(r.Denom())
+
+	var terms []*big.Int
+	for q.Sign() != 0 && (maxTerms < 1 || len(terms) < maxTerms) {
+		// the Euclidean division floors as q > 0
+		a, m := new(big.Int).DivMod(p, q, new(big.Int))
+		terms = append(terms, a)
+		p, q = q, m
+	}
+	return terms
+}
+
+// ApproxRational returns the fraction closest to d whose denominator is at
+// most maxDenominator. It's a convergent of the continued fraction of d, or a
+// semiconvergent between two of them; of two equally close fractions, the
+// convergent is returned. The result is newly allocated, it's nil for NaN and
+// infinities. ApproxRational panics if maxDenominator < 1.
+//
+// Example:
+//
+//	NewFromFloat(3.14159265).ApproxRational(1000) // output: 355/113
+//	NewFromFloat(0.333).ApproxRational(10)        // output: 1/3
+func (d Decimal) ApproxRational(maxDenominator int64) *big.Rat {
+	if maxDenominator < 1 {
+		panic(fmt.Sprintf("Cannot approximate with maximum denominator %d", maxDenominator))
+	}
+	if d.form != formFinite {
+		return nil
+	}
+	r := d.Rat()
+	max := big.NewInt(maxDenominator)
+	if r.Denom().Cmp(max) <= 0 {
+		return new(big.Rat).Set(r)
+	}
+
+	// the convergents h1/k1 follow h0/k0, starting from 1/0 and 0/1
+	p, q := new(big.Int).Set(r.Num()), new(big.Int).Set(r.Denom())
+	h0, k0 := big.NewInt(0), big.NewInt(1)
+	h1, k1 := big.NewInt(1), big.NewInt(0)
+	a, m := new(big.Int), new(big.Int)
+	for {
+		a.DivMod(p, q, m)
+		k := new(big.Int).Mul(a, k1)
+		k.Add(k, k0)
+		if k.Cmp(max) > 0 {
+			break
+		}
+		h := new(big.Int).Mul(a, h1)
+		h.Add(h, h0)
+		h0, k0, h1, k1 = h1, k1, h, k
+		p, q = q, new(big.Int).Set(m)
+	}
+
+	// the semiconvergent with the largest denominator within the bound,
+	// (t*h1 + h0) / (t*k1 + k0), may be closer than the last convergent
+	t := new(big.Int).Sub(max, k0)
+	t.Quo(t, k1)
+	h := new(big.Int).Mul(t, h1)
+	h.Add(h, h0)
+	k := new(big.Int).Mul(t, k1)
+	k.Add(k, k0)
+	semi := new(big.Rat).SetFrac(h, k)
+	conv := new(big.Rat).SetFrac(h1, k1)
+
+	distSemi := new(big.Rat).Sub(semi, r)
+	distConv := new(big.Rat).Sub(conv, r)
+	if distSemi.Abs(distSemi).Cmp(distConv.Abs(distConv)) < 0 {
+		return semi
+	}
+	return conv
+}
+
+// StringMixed returns the fraction closest to d with a denominator of at most
+// maxDenominator as a mixed number, such as "1 3/8", "-3/8" or "2", see
+// ApproxRational. NaN and infinities are formatted like String. StringMixed
+// panics if maxDenominator < 1.
+//
+// Example:
+//
+//	NewFromFloat(1.375).StringMixed(16)   // output: "1 3/8"
+//	NewFromFloat(-2.3333).StringMixed(10) // output: "-2 1/3"
+//	NewFromFloat(0.249).StringMixed(10)   // output: "1/4"
+func (d Decimal) StringMixed(maxDenominator int64) string {
+	if d.form != formFinite {
+		return d.String()
+	}
+	r := d.ApproxRational(maxDenominator)
+	if r.IsInt() {
+		return r.Num().String()
+	}
+
+	sign := ""
+	if r.Sign() < 0 {
+		sign = "-"
+	}
+	num := new(big.Int).Abs(r.Num())
+	whole, rem := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if whole.Sign() == 0 {
+		return fmt.Sprintf("%s%s/%s", sign, rem, r.Denom())
+	}
+	return fmt.Sprintf("%s%s %s/%s", sign, whole, rem, r.Denom())
+}
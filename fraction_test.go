@@ -0,0 +1,103 @@
+package decimal
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDecimal_ContinuedFraction(t *testing.T) {
+	tests := []struct {
+		input    string
+		maxTerms int
+		want     string
+	}{
+		{"3.245", 0, "[3 4 12 4]"},
+		{"-0.5", 0, "[-1 2]"},
+		{"-2.75", 0, "[-3 4]"},
+		{"0", 0, "[0]"},
+		{"7", 0, "[7]"},
+		{"1.000001", 0, "[1 1000000]"},
+		{"3.14159265358979", 5, "[3 7 15 1 292]"},
+		{"3.14159265358979", -1, "[3 7 15 1 292 1 1 1 2 1 3 1 12 2 4 1 1 3 2 2 1 18 1 2 2 1 7 2 2]"},
+		{"0.1234567890123456789", 0, "[0 8 9 1 137173 3 4 2 2 1 655 2 1 1 3 2 2 16 6 2 4 1 57 2]"},
+		{"123e20", 1, "[12300000000000000000000]"},
+	}
+	for _, test := range tests {
+		d := RequireFromString(test.input)
+		got := fmt.Sprint(d.ContinuedFraction(test.maxTerms))
+		if got != test.want {
+			t.Errorf("expected continued fraction %s of %s, got %s", test.want, d, got)
+		}
+	}
+}
+
+func TestDecimal_ApproxRational(t *testing.T) {
+	tests := []struct {
+		input          string
+		maxDenominator int64
+		want           string
+		mixed          string
+	}{
+		{"3.14159265", 1000, "355/113", "3 16/113"},
+		{"3.14159265358979", 100, "311/99", "3 14/99"},
+		{"0.333", 10, "1/3", "1/3"},
+		{"2.5", 1, "2/1", "2"},
+		{"-2.5", 1, "-3/1", "-3"},
+		{"0.1", 1, "0/1", "0"},
+		{"1.375", 16, "11/8", "1 3/8"},
+		{"-1.375", 16, "-11/8", "-1 3/8"},
+		{"-2.3333", 10, "-7/3", "-2 1/3"},
+		{"0.249", 10, "1/4", "1/4"},
+		{"-0.249", 10, "-1/4", "-1/4"},
+		{"1.41421356237", 1000000, "1217471/860882", "1 356589/860882"},
+		{"-0.0001", 3, "0/1", "0"},
+		{"0.75", 100, "3/4", "3/4"},
+		{"1e-30", 1000, "0/1", "0"},
+		{"123456789.987654321", 1000, "9999999989/81", "123456789 80/81"},
+	}
+	for _, test := range tests {
+		d := RequireFromString(test.input)
+		if got := d.ApproxRational(test.maxDenominator).String(); got != test.want {
+			t.Errorf("expected %s for %s with maximum denominator %d, got %s", test.want, d, test.maxDenominator, got)
+		}
+		if got := d.StringMixed(test.maxDenominator); got != test.mixed {
+			t.Errorf("expected %s for %s with maximum denominator %d, got %s", test.mixed, d, test.maxDenominator, got)
+		}
+	}
+
+	if got := Inf(-1).StringMixed(10); got != "-Inf" {
+		t.Errorf("expected -Inf, got %s", got)
+	}
+	for _, d := range []Decimal{NaN(), Inf(1), Inf(-1)} {
+		if r := d.ApproxRational(10); r != nil {
+			t.Errorf("expected nil for %s, got %s", d, r)
+		}
+		if terms := d.ContinuedFraction(3); terms != nil {
+			t.Errorf("expected no terms for %s, got %v", d, terms)
+		}
+	}
+
+	// the result doesn't alias d
+	d := New(3, -1)
+	r := d.ApproxRational(10)
+	r.SetInt64(7)
+	if !d.Equal(New(3, -1)) || d.ApproxRational(10).String() != "3/10" {
+		t.Errorf("expected 0.3 to be unchanged, got %s", d)
+	}
+}
+
+func TestDecimal_ApproxRationalPanics(t *testing.T) {
+	for _, f := range []func(){
+		func() { New(1, 0).ApproxRational(0) },
+		func() { NaN().ApproxRational(0) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic")
+				}
+			}()
+			f()
+		}()
+	}
+}
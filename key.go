@@ -0,0 +1,93 @@
+package decimal
+
+import (
+	"math/big"
+)
+
+// Key is a comparable representation of a Decimal, equal for numerically equal
+// decimals such as 1.0 and 1.00. Contrary to Decimal, which holds a pointer,
+// it can be compared with == and used as map key:
+//
+//	totals := make(map[decimal.Key]decimal.Decimal)
+//	totals[price.Key()] = totals[price.Key()].Add(quantity)
+//
+// All NaNs share the same key, as Cmp considers them equal.
+type Key struct {
+	compact int64
+	// coefficient in base 10 when it doesn't fit into an int64
+	big  string
+	exp  int32
+	form form
+	neg  bool
+}
+
+// Key returns the comparable representation of the normalized value of d.
+// It allocates only when the coefficient doesn't fit into an int64.
+func (d Decimal) Key() Key {
+	switch d.form {
+	case formNaN, formSNaN:
+		return Key{form: formNaN}
+	case formInfinite:
+		return Key{form: formInfinite, neg: d.neg}
+	}
+
+	n := d.Normalize()
+	if n.value == nil {
+		return Key{compact: n.compact, exp: n.exp}
+	}
+	if n.value.IsInt64() {
+		return Key{compact: n.value.Int64(), exp: n.exp}
+	}
+	return Key{big: n.value.String(), exp: n.exp}
+}
+
+// Decimal returns the normalized value represented by k.
+func (k Key) Decimal() Decimal {
+	if k.form != formFinite {
+		return Decimal{form: k.form, neg: k.neg}
+	}
+	if k.big == "" {
+		return New(k.compact, k.exp)
+	}
+	value, _ := new(big.Int).SetString(k.big, 10)
+	return Decimal{value: value, exp: k.exp}
+}
+
+// FNV-1a parameters, see https://en.wikipedia.org/wiki/Fowler–Noll–Vo_hash_function
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// Hash64 returns a 64-bit FNV-1a hash of the normalized value of d, which is
+// the same for numerically equal decimals, like Key. It doesn't allocate when
+// the coefficient fits into an int64.
+func (d Decimal) Hash64() uint64 {
+	h := uint64(fnvOffset64)
+	write := func(b byte) {
+		h ^= uint64(b)
+		h *= fnvPrime64
+	}
+	writeUint64 := func(u uint64) {
+		for i := uint(0); i < 64; i += 8 {
+			write(byte(u >> i))
+		}
+	}
+
+	k := d.Key()
+	write(byte(k.form))
+	if k.neg {
+		write(1)
+	} else {
+		write(0)
+	}
+	writeUint64(uint64(uint32(k.exp)))
+	if k.big == "" {
+		writeUint64(uint64(k.compact))
+		return h
+	}
+	for i := 0; i < len(k.big); i++ {
+		write(k.big[i])
+	}
+	return h
+}
@@ -0,0 +1,87 @@
+package decimal
+
+import (
+	"testing"
+)
+
+func TestDecimal_Normalize(t *testing.T) {
+	tests := []struct {
+		input string
+		coef  string
+		exp   int32
+	}{
+		{"1.500", "15", -1},
+		{"1200", "12", 2},
+		{"-1200.00", "-12", 2},
+		{"0.000", "0", 0},
+		{"-0", "0", 0},
+		{"7", "7", 0},
+		{"1000000000000000000000000000000", "1", 30},
+		{"123456789012345678901234567890.000", "12345678901234567890123456789", 1},
+		{"1e2147483647", "1", 2147483647},
+		{"10e2147483646", "1", 2147483647},
+	}
+	for _, test := range tests {
+		n := RequireFromString(test.input).Normalize()
+		if n.Coefficient().String() != test.coef || n.Exponent() != test.exp {
+			t.Errorf("expected %se%d for %s, got %se%d", test.coef, test.exp, test.input, n.Coefficient(), n.Exponent())
+		}
+		if n.Signbit() && n.Sign() == 0 {
+			t.Errorf("expected %s to normalize to a positive zero", test.input)
+		}
+	}
+
+	if n := Inf(-1).Normalize(); !n.IsInf(-1) {
+		t.Errorf("expected -Inf, got %s", n)
+	}
+}
+
+func TestDecimal_Key(t *testing.T) {
+	groups := [][]Decimal{
+		{New(1, 0), RequireFromString("1.0"), RequireFromString("1.000"), New(100, -2)},
+		{New(-15, -1), RequireFromString("-1.50"), RequireFromString("-0.00015e4")},
+		{New(0, 0), New(0, 5), RequireFromString("-0.00"), Zero},
+		{New(12, 3), RequireFromString("12000"), RequireFromString("12000.00000000000000000000000000")},
+		{RequireFromString("123456789012345678901234567890"), RequireFromString("123456789012345678901234567890.00")},
+		{NewFromBigInt(RequireFromString("1e20").BigInt(), 0), New(1, 20)},
+		{NaN(), SignalingNaN(), RequireFromString("NaN")},
+		{Inf(1), RequireFromString("Infinity")},
+		{Inf(-1)},
+	}
+
+	keys := make(map[Key]int)
+	hashes := make(map[uint64]int)
+	for i, group := range groups {
+		for _, d := range group {
+			k, h := d.Key(), d.Hash64()
+			if j, ok := keys[k]; ok && j != i {
+				t.Errorf("key of %s collides with group %d", d, j)
+			}
+			if j, ok := hashes[h]; ok && j != i {
+				t.Errorf("hash of %s collides with group %d", d, j)
+			}
+			keys[k], hashes[h] = i, i
+
+			if k != group[0].Key() || h != group[0].Hash64() {
+				t.Errorf("expected %s and %s to have the same key and hash", d, group[0])
+			}
+			if back := k.Decimal(); back.Cmp(d) != 0 || back.String() != k.Decimal().String() {
+				t.Errorf("expected key of %s to convert back to an equal decimal, got %s", d, back)
+			}
+		}
+	}
+	if len(keys) != len(groups) || len(hashes) != len(groups) {
+		t.Errorf("expected %d distinct keys and hashes, got %d and %d", len(groups), len(keys), len(hashes))
+	}
+}
+
+func TestDecimal_KeyAllocs(t *testing.T) {
+	d := RequireFromString("1234.5600")
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = d.Key()
+		_ = d.Hash64()
+	})
+	if allocs > 0 {
+		t.Errorf("expected no allocations, got %v", allocs)
+	}
+}
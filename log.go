@@ -0,0 +1,281 @@
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Log10 returns the decimal logarithm of d, correctly rounded half up to
+// precision digits after the decimal point. Negative precision is allowed.
+// The logarithm of a power of ten is exact.
+//
+// Example:
+//
+//	d1, err := NewFromInt(1000).Log10(4)
+//	d1.String() // output: "3"
+//
+//	d2, err := NewFromInt(2).Log10(10)
+//	d2.String() // output: "0.3010299957"
+func (d Decimal) Log10(precision int32) (Decimal, error) {
+	if r, ok, err := d.logSpecial(); ok || err != nil {
+		return r, err
+	}
+
+	n := d.Normalize()
+	if n.value == nil && n.compact == 1 {
+		return New(int64(n.exp), 0).Round(precision), nil
+	}
+	return d.logBase(precision, math.Log10(math.Ln10), func(places int32) (Decimal, error) {
+		return ln10.withPrecision(places), nil
+	})
+}
+
+// Log2 returns the binary logarithm of d, correctly rounded half up to
+// precision digits after the decimal point. Negative precision is allowed.
+// The logarithm of a power of two, such as 1024 or 0.125, is exact.
+//
+// Example:
+//
+//	d1, err := NewFromFloat(0.125).Log2(2)
+//	d1.String() // output: "-3"
+//
+//	d2, err := NewFromInt(10).Log2(10)
+//	d2.String() // output: "3.3219280949"
+func (d Decimal) Log2(precision int32) (Decimal, error) {
+	if r, ok, err := d.logSpecial(); ok || err != nil {
+		return r, err
+	}
+
+	// a power of two 2^k has the normalized coefficient 2^k for k >= 0,
+	// and 5^-k with the exponent k for k < 0, as 2^k = 5^-k * 10^k
+	n := d.Normalize()
+	c := n.coef()
+	switch {
+	case n.exp == 0 && new(big.Int).And(c, new(big.Int).Sub(c, oneInt)).Sign() == 0:
+		return New(int64(c.BitLen()-1), 0).Round(precision), nil
+	case n.exp < 0 && -int64(n.exp) <= int64(c.BitLen()):
+		if p, _ := New(5, 0).PowInt32(-n.exp); p.Equal(newDecimal(c, 0)) {
+			return New(int64(n.exp), 0).Round(precision), nil
+		}
+	}
+
+	return d.logBase(precision, math.Log10(math.Ln2), func(places int32) (Decimal, error) {
+		return ln2.withPrecision(places), nil
+	})
+}
+
+// Log returns the logarithm of d to the given base, correctly rounded half up
+// to precision digits after the decimal point. Negative precision is allowed.
+// A rational logarithm, such as that of an integer power of the base or
+// log_4(2) = 0.5, is exact, so that it's also rounded correctly when it lies
+// halfway between two rounded values.
+//
+// Log returns an error if the base isn't a finite positive number other than 1.
+//
+// Example:
+//
+//	d1, err := NewFromInt(81).Log(NewFromInt(3), 2)
+//	d1.String() // output: "4"
+//
+//	d2, err := NewFromInt(100).Log(NewFromFloat(1.5), 6)
+//	d2.String() // output: "11.357747"
+func (d Decimal) Log(base Decimal, precision int32) (Decimal, error) {
+	if base.form != formFinite || base.Sign() <= 0 || base.Equal(New(1, 0)) {
+		return Decimal{}, fmt.Errorf("cannot calculate logarithm to base %s", base)
+	}
+	if r, ok, err := d.logSpecial(); ok || err != nil {
+		if r.IsInf(0) && base.LessThan(New(1, 0)) {
+			r = r.Neg()
+		}
+		return r, err
+	}
+
+	lnBase := log10Abs(base) * math.Ln10
+	if p, q, ok := d.ratLog(base, log10Abs(d)*math.Ln10/lnBase); ok {
+		return New(p, 0).DivRound(New(q, 0), precision), nil
+	}
+
+	return d.logBase(precision, math.Log10(math.Abs(lnBase)), base.Ln)
+}
+
+// Log1p returns the natural logarithm of 1 + d, rounded half up to precision
+// digits after the decimal point. Contrary to float64, the sum 1 + d is exact,
+// so that no digits of d are lost when d is close to zero. A precision large
+// enough to show the significant digits of the result is still required.
+//
+// Log1p returns an error if d <= -1.
+//
+// Example:
+//
+//	d, err := NewFromFloat(0.0001).Log1p(12)
+//	d.String() // output: "0.000099995"
+func (d Decimal) Log1p(precision int32) (Decimal, error) {
+	if d.form == formFinite || d.IsInf(-1) {
+		if d.Cmp(New(-1, 0)) <= 0 {
+			return Decimal{}, fmt.Errorf("cannot calculate natural logarithm of 1 + %s", d)
+		}
+	}
+	if d.form != formFinite {
+		return d, nil
+	}
+	if d.IsZero() {
+		return New(0, 0).Round(precision), nil
+	}
+
+	x := d.Add(New(1, 0))
+	return roundApprox(precision, x.Ln)
+}
+
+// Expm1 returns e**d - 1, rounded half up to precision digits after the
+// decimal point. Like Log1p, it doesn't lose digits when d is close to zero.
+// It returns ErrExpTooManyDigits for large arguments like Exp, and -1 rounded
+// to precision places for large negative ones.
+//
+// Example:
+//
+//	d, err := NewFromFloat(0.0001).Expm1(12)
+//	d.String() // output: "0.000100005"
+func (d Decimal) Expm1(precision int32) (Decimal, error) {
+	if d.form != formFinite {
+		if d.IsInf(-1) {
+			return New(-1, 0), nil
+		}
+		return d, nil
+	}
+	if d.IsZero() {
+		return New(0, 0).Round(precision), nil
+	}
+
+	one := New(1, 0)
+	if d.Abs().GreaterThan(one) {
+		// e**d is at least e or at most 1/e, so that subtracting 1 loses no
+		// digits, and Exp bounds the computation
+		if -d.InexactFloat64()*math.Log10E > float64(precision)+2 {
+			// e**d < 10^-(precision+2) doesn't change the rounding of -1
+			return New(-1, 0).Round(precision), nil
+		}
+		return roundApprox(precision, func(places int32) (Decimal, error) {
+			e, err := d.Exp(places)
+			if err != nil {
+				return Decimal{}, err
+			}
+			return e.Sub(one), nil
+		})
+	}
+	return roundApprox(precision, func(places int32) (Decimal, error) {
+		e, err := d.ExpTaylor(places + 2)
+		if err != nil {
+			return Decimal{}, err
+		}
+		return e.Sub(one), nil
+	})
+}
+
+// ratLog returns the logarithm of d to the given base as the fraction p / q if
+// it's rational with q <= 64 and abs(p) <= 4096. The fraction is the first
+// convergent of the continued fraction of est, the float estimate of the
+// logarithm, that is close to est, and is checked exactly as d^q = base^p.
+func (d Decimal) ratLog(base Decimal, est float64) (p, q int64, ok bool) {
+	// the convergents h1 / k1 follow from h0 / k0 and the partial quotients
+	h0, h1 := int64(0), int64(1)
+	k0, k1 := int64(1), int64(0)
+	x := est
+	for {
+		a := math.Floor(x)
+		if math.Abs(a) > 4096 {
+			return 0, 0, false
+		}
+		h0, h1 = h1, int64(a)*h1+h0
+		k0, k1 = k1, int64(a)*k1+k0
+		if k1 > 64 || h1 > 4096 || h1 < -4096 {
+			return 0, 0, false
+		}
+		if math.Abs(est-float64(h1)/float64(k1)) <= 1e-9*math.Max(1, math.Abs(est)) {
+			break
+		}
+		x = 1 / (x - a)
+	}
+
+	dq, _ := d.PowInt32(int32(k1))
+	bp, _ := base.PowInt32(int32(abs64(h1)))
+	if h1 < 0 {
+		// d^q = base^p is d^q * base^-p = 1
+		if !dq.Mul(bp).Equal(New(1, 0)) {
+			return 0, 0, false
+		}
+	} else if !dq.Equal(bp) {
+		return 0, 0, false
+	}
+	return h1, k1, true
+}
+
+// logSpecial handles the logarithms of special values, zero and negative
+// numbers, for which ok is set or an error returned.
+func (d Decimal) logSpecial() (r Decimal, ok bool, err error) {
+	switch {
+	case d.IsNaN():
+		return NaN(), true, nil
+	case d.IsInf(1):
+		return d, true, nil
+	case d.Signbit() && !d.IsZero():
+		return Decimal{}, false, fmt.Errorf("cannot calculate logarithm for negative decimals")
+	case d.IsZero():
+		return Decimal{}, false, fmt.Errorf("cannot represent logarithm of 0, result: -infinity")
+	}
+	return Decimal{}, false, nil
+}
+
+// logBase returns ln(d) / ln(base), where lnBase approximates ln(base) with
+// the given number of places, and lnBaseAdj is the decimal logarithm of
+// abs(ln(base)).
+func (d Decimal) logBase(precision int32, lnBaseAdj float64, lnBase func(places int32) (Decimal, error)) (Decimal, error) {
+	// The error of the quotient ln(d) / ln(base) is about
+	// err(ln(d)) / ln(base) + ln(d) * err(ln(base)) / ln(base)^2,
+	// the extra digits compensate the factors. Ln also loses the digits of the
+	// exponent of d when reducing its argument.
+	lnD := math.Abs(log10Abs(d) * math.Ln10)
+	extra := int32(math.Ceil(math.Max(-lnBaseAdj, math.Log10(lnD+1)-2*lnBaseAdj))) + 2
+	if adj := d.adjusted(); adj != 0 {
+		extra += int32(numDigits64(int64(adj)))
+	}
+
+	return roundApprox(precision, func(places int32) (Decimal, error) {
+		num, err := d.Ln(places + extra)
+		if err != nil {
+			return Decimal{}, err
+		}
+		den, err := lnBase(places + extra)
+		if err != nil {
+			return Decimal{}, err
+		}
+		return num.DivRound(den, places+1), nil
+	})
+}
+
+// roundApprox rounds the result of f, which approximates a value to the given
+// number of places with an error of about a unit in the last place, half up to
+// precision places. The number of places is increased while the approximation
+// is too close to the middle of two rounded values to decide the rounding.
+//
+// An approximation can't tell a value exactly in the middle from a value close
+// to it, so that such a value is rounded by the side of its approximation.
+// Callers handle the results that can be exact midpoints themselves, such as
+// the rational logarithms of Log, while the results of the transcendental
+// functions of rational arguments, such as e**d for d != 0, are irrational.
+func roundApprox(precision int32, f func(places int32) (Decimal, error)) (Decimal, error) {
+	var r Decimal
+	for guard := int32(4); guard <= 64; guard *= 2 {
+		places := precision + guard
+		a, err := f(places)
+		if err != nil {
+			return Decimal{}, err
+		}
+		r = a.Round(precision)
+		ulps := New(2, -places)
+		if a.Sub(ulps).Round(precision).Equal(a.Add(ulps).Round(precision)) {
+			break
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,153 @@
+package decimal
+
+import (
+	"testing"
+)
+
+func TestDecimal_Log10Log2(t *testing.T) {
+	tests := []struct {
+		input     string
+		precision int32
+		log10     string
+		log2      string
+	}{
+		{"2", 10, "0.3010299957", "1"},
+		{"10", 10, "1", "3.3219280949"},
+		{"3", 20, "0.4771212547196624373", "1.58496250072115618145"},
+		{"0.5", 15, "-0.301029995663981", "-1"},
+		{"12345.6789", 8, "4.09151498", "13.59171855"},
+		{"1e-50", 5, "-50", "-166.0964"},
+		{"1e400", 3, "400", "1328.771"},
+		{"7", 0, "1", "3"},
+		{"99999999999999999999", 30, "19.999999999999999999995657055181", "66.438561897747246957391961639379"},
+		{"1000", 4, "3", "9.9658"},
+		{"1024", 4, "3.0103", "10"},
+		{"0.125", 2, "-0.9", "-3"},
+		{"0.0001", 2, "-4", "-13.29"},
+		{"1", 2, "0", "0"},
+	}
+	for _, test := range tests {
+		d := RequireFromString(test.input)
+		log10, err := d.Log10(test.precision)
+		if err != nil || log10.String() != test.log10 {
+			t.Errorf("expected log10(%s) = %s, got %s and %v", d, test.log10, log10, err)
+		}
+		log2, err := d.Log2(test.precision)
+		if err != nil || log2.String() != test.log2 {
+			t.Errorf("expected log2(%s) = %s, got %s and %v", d, test.log2, log2, err)
+		}
+	}
+}
+
+func TestDecimal_Log(t *testing.T) {
+	tests := []struct {
+		input, base string
+		precision   int32
+		want        string
+	}{
+		{"81", "3", 2, "4"},
+		{"100", "1.5", 6, "11.357747"},
+		{"2", "7", 20, "0.35620718710802217651"},
+		{"1000", "0.1", 5, "-3"},
+		{"0.001", "10", 5, "-3"},
+		{"8", "4", 3, "1.5"},
+		{"2", "1.0001", 10, "6931.8183734138"},
+		{"123.456", "123.456", 4, "1"},
+		{"1", "5", 4, "0"},
+		{"2", "4", 0, "1"},
+		{"0.5", "4", 0, "-1"},
+		{"10", "100", 0, "1"},
+		{"1000", "0.01", 0, "-2"},
+		{"1.5", "2.25", 0, "1"},
+		{"4", "8", 5, "0.66667"},
+		{"32", "8", 0, "2"},
+		{"2", "1024", 2, "0.1"},
+		{"0.125", "16", 2, "-0.75"},
+	}
+	for _, test := range tests {
+		d, base := RequireFromString(test.input), RequireFromString(test.base)
+		got, err := d.Log(base, test.precision)
+		if err != nil || got.String() != test.want {
+			t.Errorf("expected log_%s(%s) = %s, got %s and %v", base, d, test.want, got, err)
+		}
+	}
+
+	if r, err := Inf(1).Log(New(5, -1), 2); err != nil || !r.IsInf(-1) {
+		t.Errorf("expected -Inf, got %s and %v", r, err)
+	}
+	for _, base := range []Decimal{New(1, 0), Zero, New(-2, 0), Inf(1), NaN()} {
+		if _, err := New(2, 0).Log(base, 2); err == nil {
+			t.Errorf("expected error for base %s", base)
+		}
+	}
+}
+
+func TestDecimal_LogErrors(t *testing.T) {
+	for _, d := range []Decimal{Zero, New(-1, 0), Inf(-1)} {
+		if _, err := d.Log10(2); err == nil {
+			t.Errorf("expected error for log10(%s)", d)
+		}
+		if _, err := d.Log2(2); err == nil {
+			t.Errorf("expected error for log2(%s)", d)
+		}
+	}
+	if r, err := NaN().Log10(2); err != nil || !r.IsNaN() {
+		t.Errorf("expected NaN, got %s and %v", r, err)
+	}
+	if r, err := Inf(1).Log2(2); err != nil || !r.IsInf(1) {
+		t.Errorf("expected Inf, got %s and %v", r, err)
+	}
+}
+
+func TestDecimal_Log1pExpm1(t *testing.T) {
+	tests := []struct {
+		input     string
+		precision int32
+		log1p     string
+		expm1     string
+	}{
+		{"0.0001", 12, "0.000099995", "0.000100005"},
+		{"1e-20", 30, "0.00000000000000000001", "0.00000000000000000001"},
+		{"-1e-10", 25, "-0.000000000100000000005", "-0.0000000000999999999950"},
+		{"-0.5", 20, "-0.69314718055994530942", "-0.3934693402873665764"},
+		{"3", 10, "1.3862943611", "19.0855369232"},
+		{"1.5", 20, "0.91629073187415506518", "3.4816890703380648226"},
+		{"-0.75", 20, "-1.38629436111989061883", "-0.52763344725898529286"},
+		{"0", 4, "0", "0"},
+	}
+	for _, test := range tests {
+		d := RequireFromString(test.input)
+		log1p, err := d.Log1p(test.precision)
+		if err != nil || !log1p.Equal(RequireFromString(test.log1p)) {
+			t.Errorf("expected log1p(%s) = %s, got %s and %v", d, test.log1p, log1p, err)
+		}
+		expm1, err := d.Expm1(test.precision)
+		if err != nil || !expm1.Equal(RequireFromString(test.expm1)) {
+			t.Errorf("expected expm1(%s) = %s, got %s and %v", d, test.expm1, expm1, err)
+		}
+	}
+
+	for _, d := range []Decimal{New(-1, 0), New(-2, 0), Inf(-1)} {
+		if _, err := d.Log1p(2); err == nil {
+			t.Errorf("expected error for log1p(%s)", d)
+		}
+	}
+	if r, err := Inf(-1).Expm1(2); err != nil || r.String() != "-1" {
+		t.Errorf("expected -1, got %s and %v", r, err)
+	}
+
+	// large arguments are bounded by Exp instead of a series
+	for _, d := range []string{"-20", "-1e5", "-1e300"} {
+		if r, err := RequireFromString(d).Expm1(6); err != nil || r.StringFixed(6) != "-1.000000" {
+			t.Errorf("expected -1.000000 for expm1(%s), got %s and %v", d, r, err)
+		}
+	}
+	if _, err := RequireFromString("1e300").Expm1(2); err != ErrExpTooManyDigits {
+		t.Errorf("expected ErrExpTooManyDigits, got %v", err)
+	}
+	d := RequireFromString("1e5")
+	e, _ := d.Exp(2)
+	if r, err := d.Expm1(2); err != nil || !r.Equal(e.Sub(New(1, 0))) || r.NumDigits() != 43432 {
+		t.Errorf("expected expm1(%s) = exp(%[1]s) - 1 with 43432 digits, got %d digits and %v", d, r.NumDigits(), err)
+	}
+}
@@ -0,0 +1,74 @@
+package money
+
+import (
+	"testing"
+)
+
+func TestCurrencyByCode(t *testing.T) {
+	tests := []struct {
+		code       string
+		numeric    int
+		minorUnits int32
+		cash       string
+	}{
+		{"USD", 840, 2, "0"},
+		{"EUR", 978, 2, "0"},
+		{"JPY", 392, 0, "0"},
+		{"BHD", 48, 3, "0"},
+		{"CLF", 990, 4, "0"},
+		{"CHF", 756, 2, "0.05"},
+		{"SEK", 752, 2, "1"},
+		{"HUF", 348, 2, "5"},
+		{"XAU", 959, -1, "0"},
+	}
+	for _, test := range tests {
+		c, ok := CurrencyByCode(test.code)
+		if !ok {
+			t.Errorf("expected currency %s", test.code)
+			continue
+		}
+		if c.Code != test.code || c.Numeric != test.numeric || c.MinorUnits != test.minorUnits || c.CashIncrement.String() != test.cash {
+			t.Errorf("unexpected currency %+v for %s", c, test.code)
+		}
+		if n, ok := CurrencyByNumeric(test.numeric); !ok || n.Code != test.code {
+			t.Errorf("expected currency %s for %d, got %s", test.code, test.numeric, n.Code)
+		}
+	}
+
+	for _, code := range []string{"", "usd", "ABC"} {
+		if _, ok := CurrencyByCode(code); ok {
+			t.Errorf("expected no currency %q", code)
+		}
+	}
+	if _, ok := CurrencyByNumeric(0); ok {
+		t.Errorf("expected no currency 0")
+	}
+}
+
+func TestCurrencies(t *testing.T) {
+	cs := Currencies()
+	if len(cs) != len(byNumeric) {
+		t.Errorf("expected as many currencies as numeric codes, got %d and %d", len(cs), len(byNumeric))
+	}
+	for i := 1; i < len(cs); i++ {
+		if cs[i-1].Code >= cs[i].Code {
+			t.Errorf("expected %s before %s", cs[i].Code, cs[i-1].Code)
+		}
+	}
+}
+
+func TestCurrency_Text(t *testing.T) {
+	var c Currency
+	if err := c.UnmarshalText([]byte("GBP")); err != nil || c.Numeric != 826 {
+		t.Errorf("expected GBP, got %+v, %v", c, err)
+	}
+	if text, _ := c.MarshalText(); string(text) != "GBP" {
+		t.Errorf("expected GBP, got %s", text)
+	}
+	if err := c.UnmarshalText([]byte("")); err != nil || c.Code != "" {
+		t.Errorf("expected zero currency, got %+v, %v", c, err)
+	}
+	if err := c.UnmarshalText([]byte("GB")); err == nil {
+		t.Errorf("expected error for unknown currency")
+	}
+}
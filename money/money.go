@@ -0,0 +1,304 @@
+// Package money pairs decimals with the currencies of ISO 4217.
+//
+// Arithmetic on Money refuses to mix currencies, returning
+// ErrCurrencyMismatch, and rounding follows the minor units and cash rounding
+// increments of the currencies.
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrCurrencyMismatch is returned by operations on amounts of different
+// currencies.
+var ErrCurrencyMismatch = errors.New("money: currencies differ")
+
+// Money is an amount in a currency.
+type Money struct {
+	Amount   decimal.Decimal
+	Currency Currency
+}
+
+// New returns the amount in the currency with the given alphabetic code, or an
+// error if the code is unknown.
+func New(amount decimal.Decimal, code string) (Money, error) {
+	c, ok := CurrencyByCode(code)
+	if !ok {
+		return Money{}, fmt.Errorf("money: unknown currency %q", code)
+	}
+	return Money{Amount: amount, Currency: c}, nil
+}
+
+// RequireNew returns the amount in the currency with the given alphabetic
+// code, or panics if the code is unknown.
+func RequireNew(amount decimal.Decimal, code string) Money {
+	m, err := New(amount, code)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Parse parses an amount followed by a currency code, such as "12.34 USD", as
+// formatted by String. An amount without code, such as "0" for the zero Money,
+// has the zero Currency, like String formats it.
+func Parse(s string) (Money, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 1 && len(fields) != 2 {
+		return Money{}, fmt.Errorf("money: can't parse %q as an amount and a currency", s)
+	}
+	amount, err := decimal.NewFromString(fields[0])
+	if err != nil {
+		return Money{}, err
+	}
+	if len(fields) == 1 {
+		return Money{Amount: amount}, nil
+	}
+	return New(amount, fields[1])
+}
+
+// RequireParse returns the result of Parse, or panics if s can't be parsed.
+func RequireParse(s string) Money {
+	m, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// Add returns m + m2, or ErrCurrencyMismatch if their currencies differ.
+func (m Money) Add(m2 Money) (Money, error) {
+	if m.Currency.Code != m2.Currency.Code {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount.Add(m2.Amount), Currency: m.Currency}, nil
+}
+
+// Sub returns m - m2, or ErrCurrencyMismatch if their currencies differ.
+func (m Money) Sub(m2 Money) (Money, error) {
+	if m.Currency.Code != m2.Currency.Code {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount.Sub(m2.Amount), Currency: m.Currency}, nil
+}
+
+// Mul returns m * d, such as a price multiplied by a quantity. The amount isn't
+// rounded, see Round.
+func (m Money) Mul(d decimal.Decimal) Money {
+	return Money{Amount: m.Amount.Mul(d), Currency: m.Currency}
+}
+
+// Neg returns -m.
+func (m Money) Neg() Money {
+	return Money{Amount: m.Amount.Neg(), Currency: m.Currency}
+}
+
+// Abs returns the absolute value of m.
+func (m Money) Abs() Money {
+	return Money{Amount: m.Amount.Abs(), Currency: m.Currency}
+}
+
+// Cmp compares the amounts of m and m2 like decimal.Decimal.Cmp, or returns
+// ErrCurrencyMismatch if their currencies differ.
+func (m Money) Cmp(m2 Money) (int, error) {
+	if m.Currency.Code != m2.Currency.Code {
+		return 0, ErrCurrencyMismatch
+	}
+	return m.Amount.Cmp(m2.Amount), nil
+}
+
+// Equal returns whether m and m2 have the same currency and equal amounts.
+func (m Money) Equal(m2 Money) bool {
+	return m.Currency.Code == m2.Currency.Code && m.Amount.Equal(m2.Amount)
+}
+
+// IsZero returns whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount.IsZero()
+}
+
+// Sign returns -1, 0 or 1 for a negative, zero or positive amount.
+func (m Money) Sign() int {
+	return m.Amount.Sign()
+}
+
+// Round rounds the amount half away from zero to the minor units of the
+// currency. Amounts of currencies without minor unit are returned unchanged.
+//
+// Example:
+//
+//	money.RequireParse("2.345 EUR").Round().String() // output: "2.35 EUR"
+//	money.RequireParse("2.5 JPY").Round().String()   // output: "3 JPY"
+func (m Money) Round() Money {
+	return m.RoundMode(decimal.RoundHalfUp)
+}
+
+// RoundMode rounds the amount to the minor units of the currency using the
+// given rounding mode. Amounts of currencies without minor unit are returned
+// unchanged.
+func (m Money) RoundMode(mode decimal.RoundingMode) Money {
+	if m.Currency.MinorUnits < 0 {
+		return m
+	}
+	return Money{Amount: m.Amount.RoundMode(m.Currency.MinorUnits, mode), Currency: m.Currency}
+}
+
+// RoundCash rounds the amount half away from zero to a multiple of the cash
+// increment of the currency, or like Round if it has none.
+//
+// Example:
+//
+//	money.RequireParse("3.275 CHF").RoundCash().String() // output: "3.30 CHF"
+//	money.RequireParse("3.27 USD").RoundCash().String()  // output: "3.27 USD"
+func (m Money) RoundCash() Money {
+	inc := m.Currency.CashIncrement
+	if inc.IsZero() {
+		return m.Round()
+	}
+	return Money{Amount: m.Amount.DivRound(inc, 0).Mul(inc), Currency: m.Currency}
+}
+
+// Allocate distributes m across parts proportional to the ratios, in minor
+// units of the currency, so that the parts sum up exactly to m. See
+// decimal.Allocate for the distribution and the errors. Amounts of currencies
+// without minor unit are allocated in units of their last decimal place.
+//
+// Example:
+//
+//	parts, err := money.RequireParse("0.05 USD").Allocate([]decimal.Decimal{decimal.NewFromInt(3), decimal.NewFromInt(7)})
+//	// output: [0.02 USD 0.03 USD]
+func (m Money) Allocate(ratios []decimal.Decimal) ([]Money, error) {
+	amounts, err := decimal.Allocate(m.Amount, ratios, m.places())
+	if err != nil {
+		return nil, err
+	}
+	return m.withAmounts(amounts), nil
+}
+
+// Split distributes m into n parts as equal as possible, in minor units of the
+// currency, so that the parts sum up exactly to m, see Allocate.
+//
+// Example:
+//
+//	parts, err := money.RequireParse("100 EUR").Split(3)
+//	// output: [33.34 EUR 33.33 EUR 33.33 EUR]
+func (m Money) Split(n int) ([]Money, error) {
+	amounts, err := decimal.Split(m.Amount, n, m.places())
+	if err != nil {
+		return nil, err
+	}
+	return m.withAmounts(amounts), nil
+}
+
+// places returns the number of decimal places to allocate the amount in.
+func (m Money) places() int32 {
+	if m.Currency.MinorUnits >= 0 {
+		return m.Currency.MinorUnits
+	}
+	if exp := m.Amount.Exponent(); exp < 0 {
+		return -exp
+	}
+	return 0
+}
+
+func (m Money) withAmounts(amounts []decimal.Decimal) []Money {
+	parts := make([]Money, len(amounts))
+	for i, a := range amounts {
+		parts[i] = Money{Amount: a, Currency: m.Currency}
+	}
+	return parts
+}
+
+// String returns the amount followed by the currency code, such as
+// "12.30 USD". Amounts are padded with zeros to the minor units of the
+// currency, unless they have more significant decimal places.
+func (m Money) String() string {
+	amount := m.Amount.String()
+	if minor := m.Currency.MinorUnits; minor > 0 && m.Amount.IsFinite() && m.Amount.Round(minor).Equal(m.Amount) {
+		amount = m.Amount.StringFixed(minor)
+	}
+	if m.Currency.Code == "" {
+		return amount
+	}
+	return amount + " " + m.Currency.Code
+}
+
+// MarshalText implements the encoding.TextMarshaler interface for XML
+// serialization, encoding m as its String.
+func (m Money) MarshalText() (text []byte, err error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface for XML
+// deserialization.
+func (m *Money) UnmarshalText(text []byte) error {
+	str := string(text)
+
+	money, err := Parse(str)
+	*m = money
+	if err != nil {
+		return fmt.Errorf("error decoding string '%s': %s", str, err)
+	}
+
+	return nil
+}
+
+// jsonMoney is the JSON encoding of Money.
+type jsonMoney struct {
+	Amount   decimal.Decimal `json:"amount"`
+	Currency string          `json:"currency"`
+}
+
+// MarshalJSON implements the json.Marshaler interface, encoding m as an object
+// such as {"amount":"12.34","currency":"USD"}. The amount is quoted unless
+// decimal.MarshalJSONWithoutQuotes is set, like a decimal.Decimal.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMoney{Amount: m.Amount, Currency: m.Currency.Code})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The amount may be
+// quoted or not. An empty currency is the zero Currency, so that the zero Money
+// round-trips.
+func (m *Money) UnmarshalJSON(moneyBytes []byte) error {
+	if string(moneyBytes) == "null" {
+		return nil
+	}
+
+	var j jsonMoney
+	if err := json.Unmarshal(moneyBytes, &j); err != nil {
+		return fmt.Errorf("error decoding string '%s': %s", string(moneyBytes), err)
+	}
+	var c Currency
+	if err := c.UnmarshalText([]byte(j.Currency)); err != nil {
+		return fmt.Errorf("error decoding string '%s': %s", string(moneyBytes), err)
+	}
+	*m = Money{Amount: j.Amount, Currency: c}
+	return nil
+}
+
+// Scan implements the sql.Scanner interface for database deserialization of
+// values formatted like String.
+func (m *Money) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		return m.UnmarshalText([]byte(v))
+
+	case []byte:
+		return m.UnmarshalText(v)
+
+	default:
+		return fmt.Errorf("could not convert value '%+v' to any known type", value)
+	}
+}
+
+// Value implements the driver.Valuer interface for database serialization,
+// storing m as its String.
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
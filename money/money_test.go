@@ -0,0 +1,219 @@
+package money
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestMoney_Arithmetic(t *testing.T) {
+	a := RequireParse("10.50 USD")
+	b := RequireParse("0.75 USD")
+	c := RequireParse("0.75 EUR")
+
+	if sum, err := a.Add(b); err != nil || sum.String() != "11.25 USD" {
+		t.Errorf("expected 11.25 USD, got %s, %v", sum, err)
+	}
+	if diff, err := b.Sub(a); err != nil || diff.String() != "-9.75 USD" {
+		t.Errorf("expected -9.75 USD, got %s, %v", diff, err)
+	}
+	if cmp, err := a.Cmp(b); err != nil || cmp != 1 {
+		t.Errorf("expected 1, got %d, %v", cmp, err)
+	}
+	if _, err := a.Add(c); err != ErrCurrencyMismatch {
+		t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+	}
+	if _, err := a.Sub(c); err != ErrCurrencyMismatch {
+		t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+	}
+	if _, err := a.Cmp(c); err != ErrCurrencyMismatch {
+		t.Errorf("expected ErrCurrencyMismatch, got %v", err)
+	}
+	if b.Equal(c) || !b.Equal(RequireParse("0.750 USD")) {
+		t.Errorf("unexpected equality of %s, %s and 0.750 USD", b, c)
+	}
+	if got := a.Mul(decimal.RequireFromString("0.2")).Neg(); got.String() != "-2.10 USD" || got.Sign() != -1 || got.Abs().Sign() != 1 {
+		t.Errorf("expected -2.10 USD, got %s", got)
+	}
+	if _, err := New(decimal.New(1, 0), "XYZ"); err == nil {
+		t.Errorf("expected error for unknown currency")
+	}
+}
+
+func TestMoney_Round(t *testing.T) {
+	tests := []struct {
+		input string
+		round string
+		cash  string
+	}{
+		{"2.345 EUR", "2.35 EUR", "2.35 EUR"},
+		{"-2.345 EUR", "-2.35 EUR", "-2.35 EUR"},
+		{"2.5 JPY", "3 JPY", "3 JPY"},
+		{"1.23456 KWD", "1.235 KWD", "1.235 KWD"},
+		{"3.275 CHF", "3.28 CHF", "3.30 CHF"},
+		{"3.274 CHF", "3.27 CHF", "3.25 CHF"},
+		{"12.49 SEK", "12.49 SEK", "12.00 SEK"},
+		{"12.50 SEK", "12.50 SEK", "13.00 SEK"},
+		{"1002.5 HUF", "1002.50 HUF", "1005.00 HUF"},
+		{"1.23456 XAU", "1.23456 XAU", "1.23456 XAU"},
+	}
+	for _, test := range tests {
+		m := RequireParse(test.input)
+		if got := m.Round().String(); got != test.round {
+			t.Errorf("expected %s for %s, got %s", test.round, test.input, got)
+		}
+		if got := m.RoundCash().String(); got != test.cash {
+			t.Errorf("expected cash %s for %s, got %s", test.cash, test.input, got)
+		}
+	}
+
+	if got := RequireParse("2.345 EUR").RoundMode(decimal.RoundHalfEven).String(); got != "2.34 EUR" {
+		t.Errorf("expected 2.34 EUR, got %s", got)
+	}
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	parts, err := RequireParse("100 EUR").Split(3)
+	if got := fmt.Sprint(parts); err != nil || got != "[33.34 EUR 33.33 EUR 33.33 EUR]" {
+		t.Errorf("expected [33.34 EUR 33.33 EUR 33.33 EUR], got %s, %v", got, err)
+	}
+	parts, err = RequireParse("-0.05 USD").Allocate([]decimal.Decimal{decimal.NewFromInt(3), decimal.NewFromInt(7)})
+	if got := fmt.Sprint(parts); err != nil || got != "[-0.02 USD -0.03 USD]" {
+		t.Errorf("expected [-0.02 USD -0.03 USD], got %s, %v", got, err)
+	}
+	parts, err = RequireParse("1000 JPY").Split(3)
+	if got := fmt.Sprint(parts); err != nil || got != "[334 JPY 333 JPY 333 JPY]" {
+		t.Errorf("expected [334 JPY 333 JPY 333 JPY], got %s, %v", got, err)
+	}
+	if _, err := RequireParse("1.005 USD").Split(2); err == nil {
+		t.Errorf("expected error for amount with more places than the minor units")
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"12.3 USD", "12.30 USD"},
+		{"12.345 USD", "12.345 USD"},
+		{"1e3 JPY", "1000 JPY"},
+		{"-0.5 BHD", "-0.500 BHD"},
+		{"1.5 XAU", "1.5 XAU"},
+	}
+	for _, test := range tests {
+		if got := RequireParse(test.input).String(); got != test.want {
+			t.Errorf("expected %s for %s, got %s", test.want, test.input, got)
+		}
+	}
+
+	for _, s := range []string{"", "12.34 USD extra", "abc USD", "12.34 usd", "USD"} {
+		if _, err := Parse(s); err == nil {
+			t.Errorf("expected error parsing %q", s)
+		}
+	}
+	if m, err := Parse("12.34"); err != nil || m.Currency != (Currency{}) || m.String() != "12.34" {
+		t.Errorf("expected 12.34 without currency, got %s, %v", m, err)
+	}
+}
+
+func TestMoney_JSON(t *testing.T) {
+	type doc struct {
+		Price Money `json:"price"`
+	}
+	d := doc{Price: RequireParse("12.3 USD")}
+	b, err := json.Marshal(d)
+	if err != nil || string(b) != `{"price":{"amount":"12.3","currency":"USD"}}` {
+		t.Errorf("unexpected JSON %s, %v", b, err)
+	}
+
+	decimal.MarshalJSONWithoutQuotes = true
+	b, err = json.Marshal(d)
+	decimal.MarshalJSONWithoutQuotes = false
+	if err != nil || string(b) != `{"price":{"amount":12.3,"currency":"USD"}}` {
+		t.Errorf("unexpected JSON %s, %v", b, err)
+	}
+
+	for _, s := range []string{
+		`{"price":{"amount":"12.3","currency":"USD"}}`,
+		`{"price":{"amount":12.3,"currency":"USD"}}`,
+	} {
+		var got doc
+		if err := json.Unmarshal([]byte(s), &got); err != nil || !got.Price.Equal(d.Price) {
+			t.Errorf("expected %s decoding %s, got %s, %v", d.Price, s, got.Price, err)
+		}
+	}
+
+	var zero doc
+	b, err = json.Marshal(zero)
+	if err != nil || string(b) != `{"price":{"amount":"0","currency":""}}` {
+		t.Errorf("unexpected JSON %s, %v", b, err)
+	}
+	if err := json.Unmarshal(b, &zero); err != nil || !zero.Price.Amount.IsZero() || zero.Price.Currency != (Currency{}) {
+		t.Errorf("expected the zero Money decoding %s, got %s, %v", b, zero.Price, err)
+	}
+
+	var got doc
+	if err := json.Unmarshal([]byte(`{"price":null}`), &got); err != nil || !got.Price.Amount.IsZero() {
+		t.Errorf("expected zero decoding null, got %s, %v", got.Price, err)
+	}
+	for _, s := range []string{
+		`{"price":{"amount":"12.3","currency":"XYZ"}}`,
+		`{"price":{"amount":"abc","currency":"USD"}}`,
+	} {
+		if err := json.Unmarshal([]byte(s), &got); err == nil {
+			t.Errorf("expected error decoding %s", s)
+		}
+	}
+}
+
+func TestMoney_TextAndSQL(t *testing.T) {
+	m := RequireParse("-7.5 GBP")
+	text, err := m.MarshalText()
+	if err != nil || string(text) != "-7.50 GBP" {
+		t.Errorf("expected -7.50 GBP, got %s, %v", text, err)
+	}
+	value, err := m.Value()
+	if err != nil || value != "-7.50 GBP" {
+		t.Errorf("expected -7.50 GBP, got %v, %v", value, err)
+	}
+
+	for _, v := range []interface{}{"-7.50 GBP", []byte("-7.5 GBP")} {
+		var got Money
+		if err := got.Scan(v); err != nil || !got.Equal(m) {
+			t.Errorf("expected %s scanning %v, got %s, %v", m, v, got, err)
+		}
+	}
+	var got Money
+	if err := got.Scan(int64(7)); err == nil {
+		t.Errorf("expected error scanning an integer")
+	}
+	for _, s := range []string{"7.5 GBP EUR", "GBP", ""} {
+		if err := got.UnmarshalText([]byte(s)); err == nil {
+			t.Errorf("expected error decoding %q", s)
+		}
+	}
+
+	// the zero Money has no currency code
+	var zero Money
+	text, err = zero.MarshalText()
+	if err != nil || string(text) != "0" {
+		t.Errorf("expected 0, got %s, %v", text, err)
+	}
+	if err := got.UnmarshalText(text); err != nil || !got.Equal(zero) || got.Currency != (Currency{}) {
+		t.Errorf("expected the zero Money decoding %s, got %s, %v", text, got, err)
+	}
+	value, err = zero.Value()
+	if err != nil || value != "0" {
+		t.Errorf("expected 0, got %v, %v", value, err)
+	}
+	got = m
+	if err := got.Scan(value); err != nil || !got.Equal(zero) || got.Currency != (Currency{}) {
+		t.Errorf("expected the zero Money scanning %v, got %s, %v", value, got, err)
+	}
+	if err := got.UnmarshalText([]byte("7.5")); err != nil || !got.Equal(Money{Amount: decimal.New(75, -1)}) {
+		t.Errorf("expected 7.5 without currency, got %s, %v", got, err)
+	}
+}
@@ -0,0 +1,229 @@
+package money
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// ErrNoRate is returned when no rate between two currencies is known, nor can
+// be derived.
+var ErrNoRate = errors.New("money: no exchange rate")
+
+// Path is the way an exchange rate was derived from the rates of a table.
+type Path int
+
+const (
+	// Identity is the rate 1 between a currency and itself.
+	Identity Path = iota
+	// Direct is a rate given for the pair.
+	Direct
+	// Inverse is the inverse of a rate given for the reversed pair.
+	Inverse
+	// Triangulated is the cross rate through the base currency of the table.
+	Triangulated
+)
+
+// String returns the name of the path, such as "direct".
+func (p Path) String() string {
+	switch p {
+	case Identity:
+		return "identity"
+	case Direct:
+		return "direct"
+	case Inverse:
+		return "inverse"
+	case Triangulated:
+		return "triangulated"
+	}
+	return fmt.Sprintf("Path(%d)", int(p))
+}
+
+// Pair is an ordered pair of currency codes, such as EUR/USD.
+type Pair struct {
+	From string
+	To   string
+}
+
+// String returns the pair as "EUR/USD".
+func (p Pair) String() string {
+	return p.From + "/" + p.To
+}
+
+// Rates is a table of exchange rates keyed by currency pair. The rate of a pair
+// is the amount of the To currency worth one unit of the From currency.
+//
+// Rates given for a pair are used as they are. Rates derived from them, the
+// inverses and the cross rates through the base currency, are computed exactly
+// and rounded once to Precision decimal places with Rounding. Converted amounts
+// are rounded to the minor units of their currency with Rounding.
+//
+// Lookups may run concurrently, but not concurrently with Set or LoadCSV.
+type Rates struct {
+	// Base is the currency through which cross rates are triangulated, or
+	// empty to not triangulate.
+	Base string
+	// Precision is the number of decimal places of derived rates.
+	Precision int32
+	// Rounding is the rounding mode of derived rates and converted amounts.
+	Rounding decimal.RoundingMode
+
+	rates map[Pair]decimal.Decimal
+}
+
+// NewRates returns an empty table triangulating through base, rounding half
+// up, with derived rates rounded to DivisionPrecision decimal places.
+func NewRates(base string) *Rates {
+	return &Rates{
+		Base:      base,
+		Precision: int32(decimal.DivisionPrecision),
+		Rounding:  decimal.RoundHalfUp,
+		rates:     make(map[Pair]decimal.Decimal),
+	}
+}
+
+// Set sets the rate of the pair from/to. It returns an error for unknown
+// currencies, or if the rate isn't finite and positive.
+func (r *Rates) Set(from, to string, rate decimal.Decimal) error {
+	for _, code := range []string{from, to} {
+		if !isCode(code) {
+			return fmt.Errorf("money: unknown currency %q", code)
+		}
+	}
+	if from == to || !rate.IsFinite() || rate.Sign() <= 0 {
+		return fmt.Errorf("money: invalid rate %s for %s/%s", rate, from, to)
+	}
+	if r.rates == nil {
+		r.rates = make(map[Pair]decimal.Decimal)
+	}
+	r.rates[Pair{From: from, To: to}] = rate
+	return nil
+}
+
+// LoadCSV sets the rates read from CSV records of the form from,to,rate, such
+// as "EUR,USD,1.0842". A first record whose fields aren't currency codes and a
+// rate, such as "from,to,rate", is skipped as a header. Blank lines are
+// ignored.
+func (r *Rates) LoadCSV(reader io.Reader) error {
+	cr := csv.NewReader(reader)
+	cr.FieldsPerRecord = 3
+	cr.TrimLeadingSpace = true
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		from, to := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		rate, err := decimal.NewFromString(strings.TrimSpace(record[2]))
+		if err != nil {
+			if line == 1 && !isCode(from) && !isCode(to) {
+				continue
+			}
+			return fmt.Errorf("money: record %d: %s", line, err)
+		}
+		if err := r.Set(from, to, rate); err != nil {
+			return fmt.Errorf("money: record %d: %s", line, err)
+		}
+	}
+}
+
+// Rate returns the rate of the pair from/to and the path it was derived by.
+// The rate is, in order of preference, 1 between a known currency and itself,
+// the rate set for the pair, the inverse of the rate set for the reversed pair,
+// or the product of the rates from/Base and Base/to, each of them set for the
+// pair or for the reversed pair. Rate returns ErrNoRate otherwise, or an error
+// for an unknown currency code like Set.
+//
+// Example:
+//
+//	rates := money.NewRates("USD")
+//	rates.Set("EUR", "USD", decimal.RequireFromString("1.25"))
+//	rates.Set("USD", "JPY", decimal.RequireFromString("150"))
+//	rates.Rate("USD", "EUR") // output: "0.8" inverse
+//	rates.Rate("EUR", "JPY") // output: "187.5" triangulated
+func (r *Rates) Rate(from, to string) (decimal.Decimal, Path, error) {
+	if from == to {
+		if !isCode(from) {
+			return decimal.Decimal{}, 0, fmt.Errorf("money: unknown currency %q", from)
+		}
+		return decimal.New(1, 0), Identity, nil
+	}
+	if rate, ok := r.rates[Pair{From: from, To: to}]; ok {
+		return rate, Direct, nil
+	}
+	if rate, ok := r.rates[Pair{From: to, To: from}]; ok {
+		return r.quo(decimal.New(1, 0), rate), Inverse, nil
+	}
+	if r.Base == "" || from == r.Base || to == r.Base {
+		return decimal.Decimal{}, 0, ErrNoRate
+	}
+	num1, den1, ok1 := r.leg(from, r.Base)
+	num2, den2, ok2 := r.leg(r.Base, to)
+	if !ok1 || !ok2 {
+		return decimal.Decimal{}, 0, ErrNoRate
+	}
+	return r.quo(num1.Mul(num2), den1.Mul(den2)), Triangulated, nil
+}
+
+// Convert converts amount from one currency into another, rounded to the minor
+// units of the target currency, and returns the path of the rate used.
+//
+// Example:
+//
+//	// with the rates of the example of Rate
+//	rates.Convert(decimal.RequireFromString("10.01"), "EUR", "JPY") // output: "1877" triangulated
+func (r *Rates) Convert(amount decimal.Decimal, from, to string) (decimal.Decimal, Path, error) {
+	c, ok := CurrencyByCode(to)
+	if !ok {
+		return decimal.Decimal{}, 0, fmt.Errorf("money: unknown currency %q", to)
+	}
+	rate, path, err := r.Rate(from, to)
+	if err != nil {
+		return decimal.Decimal{}, 0, err
+	}
+	converted := amount.Mul(rate)
+	if c.MinorUnits >= 0 {
+		converted = converted.RoundMode(c.MinorUnits, r.Rounding)
+	}
+	return converted, path, nil
+}
+
+// ConvertMoney converts m into the currency with the given code, like Convert.
+func (r *Rates) ConvertMoney(m Money, to string) (Money, Path, error) {
+	amount, path, err := r.Convert(m.Amount, m.Currency.Code, to)
+	if err != nil {
+		return Money{}, 0, err
+	}
+	c, _ := CurrencyByCode(to)
+	return Money{Amount: amount, Currency: c}, path, nil
+}
+
+// leg returns the exact rate from/to as a numerator and a denominator, from
+// the rate set for the pair or for the reversed pair.
+func (r *Rates) leg(from, to string) (num, den decimal.Decimal, ok bool) {
+	if rate, ok := r.rates[Pair{From: from, To: to}]; ok {
+		return rate, decimal.New(1, 0), true
+	}
+	if rate, ok := r.rates[Pair{From: to, To: from}]; ok {
+		return decimal.New(1, 0), rate, true
+	}
+	return decimal.Decimal{}, decimal.Decimal{}, false
+}
+
+// isCode returns whether code is the code of a known currency.
+func isCode(code string) bool {
+	_, ok := CurrencyByCode(code)
+	return ok
+}
+
+// quo returns num / den rounded to the precision of derived rates.
+func (r *Rates) quo(num, den decimal.Decimal) decimal.Decimal {
+	return num.DivRoundMode(den, r.Precision, r.Rounding)
+}
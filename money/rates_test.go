@@ -0,0 +1,141 @@
+package money
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func testRates(t *testing.T) *Rates {
+	rates := NewRates("USD")
+	err := rates.LoadCSV(strings.NewReader(`from,to,rate
+EUR,USD,1.25
+USD,JPY,150
+GBP, USD, 1.3
+
+USD,CHF,0.9
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rates
+}
+
+func TestRates_Rate(t *testing.T) {
+	rates := testRates(t)
+	tests := []struct {
+		from, to string
+		want     string
+		path     Path
+	}{
+		{"EUR", "EUR", "1", Identity},
+		{"EUR", "USD", "1.25", Direct},
+		{"USD", "EUR", "0.8", Inverse},
+		{"JPY", "USD", "0.0066666666666667", Inverse},
+		{"EUR", "JPY", "187.5", Triangulated},
+		{"JPY", "EUR", "0.0053333333333333", Triangulated},
+		{"GBP", "EUR", "1.04", Triangulated},
+		{"CHF", "GBP", "0.8547008547008547", Triangulated},
+	}
+	for _, test := range tests {
+		rate, path, err := rates.Rate(test.from, test.to)
+		if err != nil || !rate.Equal(decimal.RequireFromString(test.want)) || path != test.path {
+			t.Errorf("expected %s %s for %s/%s, got %s %s, %v", test.want, test.path, test.from, test.to, rate, path, err)
+		}
+	}
+
+	for _, pair := range []Pair{{"EUR", "SEK"}, {"SEK", "USD"}, {"USD", "SEK"}} {
+		if _, _, err := rates.Rate(pair.From, pair.To); err != ErrNoRate {
+			t.Errorf("expected ErrNoRate for %s, got %v", pair, err)
+		}
+	}
+	rates.Base = ""
+	if _, _, err := rates.Rate("EUR", "JPY"); err != ErrNoRate {
+		t.Errorf("expected ErrNoRate without base, got %v", err)
+	}
+	for _, code := range []string{"XYZ", "", "eur"} {
+		if _, _, err := rates.Rate(code, code); err == nil || err == ErrNoRate {
+			t.Errorf("expected error for unknown currency %q, got %v", code, err)
+		}
+	}
+}
+
+func TestRates_PrecisionAndRounding(t *testing.T) {
+	rates := testRates(t)
+	rates.Precision = 4
+	if rate, _, _ := rates.Rate("JPY", "EUR"); rate.String() != "0.0053" {
+		t.Errorf("expected 0.0053, got %s", rate)
+	}
+	rates.Rounding = decimal.RoundUp
+	if rate, _, _ := rates.Rate("JPY", "EUR"); rate.String() != "0.0054" {
+		t.Errorf("expected 0.0054, got %s", rate)
+	}
+	if got, _, _ := rates.Convert(decimal.RequireFromString("1.001"), "EUR", "USD"); got.String() != "1.26" {
+		t.Errorf("expected 1.26, got %s", got)
+	}
+}
+
+func TestRates_Convert(t *testing.T) {
+	rates := testRates(t)
+	got, path, err := rates.Convert(decimal.RequireFromString("10.01"), "EUR", "JPY")
+	if err != nil || got.String() != "1877" || path != Triangulated {
+		t.Errorf("expected 1877 triangulated, got %s %s, %v", got, path, err)
+	}
+	got, path, err = rates.Convert(decimal.RequireFromString("-3.33"), "EUR", "USD")
+	if err != nil || got.String() != "-4.16" || path != Direct {
+		t.Errorf("expected -4.16 direct, got %s %s, %v", got, path, err)
+	}
+
+	m, path, err := rates.ConvertMoney(RequireParse("100 CHF"), "USD")
+	if err != nil || m.String() != "111.11 USD" || path != Inverse {
+		t.Errorf("expected 111.11 USD inverse, got %s %s, %v", m, path, err)
+	}
+	if _, _, err := rates.ConvertMoney(RequireParse("100 CHF"), "XYZ"); err == nil {
+		t.Errorf("expected error for unknown currency")
+	}
+}
+
+func TestRates_Errors(t *testing.T) {
+	rates := NewRates("EUR")
+	for _, test := range []struct {
+		from, to, rate string
+	}{
+		{"EUR", "XYZ", "1"},
+		{"EUR", "EUR", "1"},
+		{"EUR", "USD", "0"},
+		{"EUR", "USD", "-1.2"},
+		{"EUR", "USD", "NaN"},
+	} {
+		if err := rates.Set(test.from, test.to, decimal.RequireFromString(test.rate)); err == nil {
+			t.Errorf("expected error setting %s/%s to %s", test.from, test.to, test.rate)
+		}
+	}
+
+	for _, csv := range []string{
+		"EUR,USD,1.1\nEUR,GBP,abc\n",
+		"EUR,USD\n",
+		"EUR,XYZ,1.1\n",
+		"EUR,USD,1.08x\nEUR,GBP,0.85\n",
+		"from,USD,rate\nEUR,USD,1.08\n",
+		"from,to,rate\nfrom,to,rate\n",
+	} {
+		if err := NewRates("EUR").LoadCSV(strings.NewReader(csv)); err == nil {
+			t.Errorf("expected error loading %q", csv)
+		}
+	}
+
+	var zero Rates
+	if err := zero.Set("EUR", "USD", decimal.New(11, -1)); err != nil {
+		t.Errorf("unexpected error setting a rate of a zero table: %v", err)
+	}
+}
+
+func TestPath_String(t *testing.T) {
+	if s := Triangulated.String(); s != "triangulated" {
+		t.Errorf("expected triangulated, got %s", s)
+	}
+	if s := Path(9).String(); s != "Path(9)" {
+		t.Errorf("expected Path(9), got %s", s)
+	}
+}
@@ -0,0 +1,267 @@
+package decimal
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrRepetendTooLong is returned by DivRepeating when the repeating part of a
+// quotient is longer than the requested maximum.
+var ErrRepetendTooLong = errors.New("decimal repetend exceeds the maximum cycle length")
+
+// overline is the combining overline placed after each digit of a repetend
+// in the RepeatingOverline style.
+const overline = '̅'
+
+// RepeatingStyle selects how Repeating.StringStyle marks the repetend.
+type RepeatingStyle int
+
+const (
+	// RepeatingParentheses encloses the repetend in parentheses, e.g. "0.1(6)".
+	RepeatingParentheses RepeatingStyle = iota
+	// RepeatingOverline draws a line over the digits of the repetend with
+	// combining overlines, e.g. "0.16̅".
+	RepeatingOverline
+)
+
+// Repeating is the exact decimal expansion of a fraction, split into the
+// digits that don't repeat and the repetend, the digits that repeat forever.
+// For example, 1/6 = 0.1666... has the prefix 0.1 and the repetend "6".
+type Repeating struct {
+	// Prefix holds the digits before the repetend, truncated towards zero.
+	// The number of decimal places of Prefix is the number of non-repeating
+	// digits after the decimal point. Prefix has the sign of the fraction,
+	// thus it's a negative zero for -1/3.
+	Prefix Decimal
+
+	// Repetend is the sequence of digits that repeats after Prefix, it's empty
+	// when the expansion terminates.
+	Repetend string
+}
+
+// DivRepeating returns the exact decimal expansion of d / d2, e.g. 0.(142857)
+// for 1/7. It returns ErrRepetendTooLong when the repetend has more than
+// maxCycle digits, which can be as many as the digits of d2 divided by its
+// powers of 2 and 5, so that maxCycle bounds the work on large divisors; with
+// a maxCycle of 0 only terminating expansions are returned. DivRepeating
+// returns an error for a negative maxCycle, and ErrDivisionByZero if d2 is
+// zero.
+//
+// Example:
+//
+//	r, _ := NewFromInt(1).DivRepeating(NewFromInt(7), 10)
+//	r.String() // output: "0.(142857)"
+//	r, _ = NewFromInt(-7).DivRepeating(NewFromInt(12), 10)
+//	r.String() // output: "-0.58(3)"
+func (d Decimal) DivRepeating(d2 Decimal, maxCycle int) (Repeating, error) {
+	if d.form != formFinite || d2.form != formFinite {
+		return Repeating{}, fmt.Errorf("can't expand %s / %s: not a finite number", d, d2)
+	}
+	if maxCycle < 0 {
+		return Repeating{}, fmt.Errorf("can't expand %s / %s: negative maximum cycle length %d", d, d2, maxCycle)
+	}
+	if d2.Sign() == 0 {
+		return Repeating{}, ErrDivisionByZero
+	}
+
+	q := new(big.Rat).Quo(d.Rat(), d2.Rat())
+	neg := q.Sign() < 0
+	num := new(big.Int).Abs(q.Num())
+	den := q.Denom()
+
+	// the digits repeat from the first place where the remaining denominator,
+	// without its factors 2 and 5, has no more divisors in common with 10^n
+	var twos, fives int
+	var r, m big.Int
+	m.Set(den)
+	for m.Bit(0) == 0 {
+		m.Rsh(&m, 1)
+		twos++
+	}
+	for {
+		var q5 big.Int
+		q5.QuoRem(&m, fiveInt, &r)
+		if r.Sign() != 0 {
+			break
+		}
+		m.Set(&q5)
+		fives++
+	}
+	places := twos
+	if fives > places {
+		places = fives
+	}
+
+	coef := new(big.Int).Mul(num, new(big.Int).Exp(tenInt, big.NewInt(int64(places)), nil))
+	coef.QuoRem(coef, den, &r)
+	var prefix Decimal
+	switch {
+	case !neg:
+		prefix = newDecimal(coef, int32(-places))
+	case coef.Sign() == 0:
+		prefix = Decimal{neg: true, exp: int32(-places)}
+	default:
+		prefix = newDecimal(coef.Neg(coef), int32(-places))
+	}
+	if r.Sign() == 0 {
+		return Repeating{Prefix: prefix}, nil
+	}
+
+	// past the prefix the expansion is purely periodic: the remainder comes
+	// back to its initial value after one cycle
+	var repetend []byte
+	var digit big.Int
+	start := new(big.Int).Set(&r)
+	for {
+		if len(repetend) == maxCycle {
+			return Repeating{}, ErrRepetendTooLong
+		}
+		r.Mul(&r, tenInt)
+		digit.QuoRem(&r, den, &r)
+		repetend = append(repetend, byte('0'+digit.Int64()))
+		if r.Cmp(start) == 0 {
+			break
+		}
+	}
+	return Repeating{Prefix: prefix, Repetend: string(repetend)}, nil
+}
+
+// String returns the expansion with the repetend in parentheses, e.g. "0.1(6)".
+func (r Repeating) String() string {
+	return r.StringStyle(RepeatingParentheses)
+}
+
+// StringStyle returns the expansion with the repetend marked in the given style.
+//
+// Example:
+//
+//	r, _ := NewFromInt(1).DivRepeating(NewFromInt(3), 10)
+//	r.StringStyle(RepeatingParentheses) // output: "0.(3)"
+//	r.StringStyle(RepeatingOverline)    // output: "0.3̅"
+func (r Repeating) StringStyle(style RepeatingStyle) string {
+	places := int32(0)
+	if r.Prefix.exp < 0 {
+		places = -r.Prefix.exp
+	}
+
+	var buf strings.Builder
+	if r.Prefix.Signbit() {
+		buf.WriteByte('-')
+	}
+	buf.WriteString(r.Prefix.Abs().StringFixed(places))
+	if r.Repetend == "" {
+		return buf.String()
+	}
+	if places == 0 {
+		buf.WriteByte('.')
+	}
+	if style == RepeatingOverline {
+		for _, c := range r.Repetend {
+			buf.WriteRune(c)
+			buf.WriteRune(overline)
+		}
+	} else {
+		buf.WriteByte('(')
+		buf.WriteString(r.Repetend)
+		buf.WriteByte(')')
+	}
+	return buf.String()
+}
+
+// Rat returns the fraction represented by the expansion.
+func (r Repeating) Rat() *big.Rat {
+	// with k non-repeating decimal places and a repetend R of n digits,
+	// prefix + R / (10^k * (10^n - 1)) = (prefix * 10^k * (10^n - 1) + R) / (10^k * (10^n - 1))
+	places := int64(0)
+	if r.Prefix.exp < 0 {
+		places = -int64(r.Prefix.exp)
+	}
+	scale := new(big.Int).Exp(tenInt, big.NewInt(places), nil)
+	num := r.Prefix.Abs().Shift(int32(places)).BigInt()
+
+	den := new(big.Int).Exp(tenInt, big.NewInt(int64(len(r.Repetend))), nil)
+	den.Sub(den, oneInt)
+	if den.Sign() == 0 {
+		den.SetInt64(1)
+	} else {
+		repetend, _ := new(big.Int).SetString(r.Repetend, 10)
+		num.Mul(num, den)
+		num.Add(num, repetend)
+	}
+	den.Mul(den, scale)
+	if r.Prefix.Signbit() {
+		num.Neg(num)
+	}
+	return new(big.Rat).SetFrac(num, den)
+}
+
+// NewFromRepeatingString returns a new Decimal from the representation of a
+// repeating decimal, with the repetend in parentheses or marked by combining
+// overlines as produced by Repeating.StringStyle. The exact fraction is
+// rounded to the given precision like NewFromBigRat.
+//
+// Example:
+//
+//	d, err := NewFromRepeatingString("0.1(6)", 4) // output: "0.1667"
+//	d2, err := NewFromRepeatingString("0.3̅", 2)   // output: "0.33"
+func NewFromRepeatingString(value string, precision int32) (Decimal, error) {
+	r, err := parseRepeating(value)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return NewFromBigRat(r.Rat(), precision), nil
+}
+
+// parseRepeating parses the representation of a repeating decimal.
+func parseRepeating(value string) (Repeating, error) {
+	prefix, repetend := value, ""
+	if i := strings.IndexByte(value, '('); i >= 0 {
+		if !strings.HasSuffix(value, ")") {
+			return Repeating{}, fmt.Errorf("can't convert %s to decimal: unbalanced parentheses", value)
+		}
+		prefix, repetend = value[:i], value[i+1:len(value)-1]
+		if repetend == "" {
+			return Repeating{}, fmt.Errorf("can't convert %s to decimal: empty repetend", value)
+		}
+	} else if strings.ContainsRune(value, overline) {
+		var digits, marked []rune
+		runes := []rune(value)
+		for i := 0; i < len(runes); i++ {
+			c := runes[i]
+			switch {
+			case i+1 < len(runes) && runes[i+1] == overline:
+				marked = append(marked, c)
+				i++
+			case c == overline:
+				return Repeating{}, fmt.Errorf("can't convert %s to decimal: misplaced overline", value)
+			case len(marked) > 0:
+				return Repeating{}, fmt.Errorf("can't convert %s to decimal: repetend must be at the end", value)
+			default:
+				digits = append(digits, c)
+			}
+		}
+		prefix, repetend = string(digits), string(marked)
+	}
+
+	for _, c := range repetend {
+		if c < '0' || c > '9' {
+			return Repeating{}, fmt.Errorf("can't convert %s to decimal: repetend is not numeric", value)
+		}
+	}
+	digits := strings.TrimLeft(prefix, "+-")
+	if len(prefix)-len(digits) > 1 || digits == "" || digits == "." ||
+		strings.Count(digits, ".") > 1 || strings.Trim(digits, "0123456789.") != "" {
+		return Repeating{}, fmt.Errorf("can't convert %s to decimal", value)
+	}
+	if repetend != "" && !strings.Contains(digits, ".") {
+		return Repeating{}, fmt.Errorf("can't convert %s to decimal: repetend must follow the decimal point", value)
+	}
+
+	d, err := NewFromString(strings.TrimSuffix(prefix, "."))
+	if err != nil {
+		return Repeating{}, err
+	}
+	return Repeating{Prefix: d, Repetend: repetend}, nil
+}
@@ -0,0 +1,121 @@
+package decimal
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestDecimal_DivRepeating(t *testing.T) {
+	tests := []struct {
+		a, b, want, overline string
+	}{
+		{"1", "7", "0.(142857)", "0.1̅4̅2̅8̅5̅7̅"},
+		{"1", "3", "0.(3)", "0.3̅"},
+		{"1", "6", "0.1(6)", "0.16̅"},
+		{"4", "3", "1.(3)", "1.3̅"},
+		{"-1", "3", "-0.(3)", "-0.3̅"},
+		{"-7", "12", "-0.58(3)", "-0.583̅"},
+		{"1", "12.5", "0.08", "0.08"},
+		{"10", "4", "2.5", "2.5"},
+		{"100", "1", "100", "100"},
+		{"0", "-3", "0", "0"},
+		{"0.5", "0.03", "16.(6)", "16.6̅"},
+		{"22", "-7", "-3.(142857)", "-3.1̅4̅2̅8̅5̅7̅"},
+		{"1", "44", "0.02(27)", "0.022̅7̅"},
+		{"123456789012345678901234567890", "99", "1247038272851976554557924928.(18)", "1247038272851976554557924928.1̅8̅"},
+	}
+	for _, test := range tests {
+		a, b := RequireFromString(test.a), RequireFromString(test.b)
+		r, err := a.DivRepeating(b, 10)
+		if err != nil {
+			t.Errorf("unexpected error for %s / %s: %v", a, b, err)
+			continue
+		}
+		if s := r.String(); s != test.want {
+			t.Errorf("expected %s for %s / %s, got %s", test.want, a, b, s)
+		}
+		if s := r.StringStyle(RepeatingOverline); s != test.overline {
+			t.Errorf("expected %s for %s / %s, got %s", test.overline, a, b, s)
+		}
+		if want := new(big.Rat).Quo(a.Rat(), b.Rat()); r.Rat().Cmp(want) != 0 {
+			t.Errorf("expected fraction %s for %s / %s, got %s", want, a, b, r.Rat())
+		}
+	}
+
+	if _, err := New(1, 0).DivRepeating(New(17, 0), 15); err != ErrRepetendTooLong {
+		t.Errorf("expected %v, got %v", ErrRepetendTooLong, err)
+	}
+	if r, err := New(1, 0).DivRepeating(New(17, 0), 16); err != nil || r.Repetend != "0588235294117647" {
+		t.Errorf("expected repetend 0588235294117647, got %s and %v", r, err)
+	}
+	for _, maxCycle := range []int{-1, -100} {
+		if _, err := New(1, 0).DivRepeating(New(3, 0), maxCycle); err == nil || err == ErrRepetendTooLong {
+			t.Errorf("expected error for maximum cycle length %d, got %v", maxCycle, err)
+		}
+	}
+	if _, err := New(1, 0).DivRepeating(New(3, 0), 0); err != ErrRepetendTooLong {
+		t.Errorf("expected %v, got %v", ErrRepetendTooLong, err)
+	}
+	if r, err := New(1, 0).DivRepeating(New(8, 0), 0); err != nil || r.String() != "0.125" {
+		t.Errorf("expected 0.125, got %s, %v", r, err)
+	}
+	if _, err := New(1, 0).DivRepeating(Zero, 10); err != ErrDivisionByZero {
+		t.Errorf("expected %v, got %v", ErrDivisionByZero, err)
+	}
+	if _, err := NaN().DivRepeating(New(1, 0), 10); err == nil {
+		t.Errorf("expected error for NaN")
+	}
+}
+
+func TestNewFromRepeatingString(t *testing.T) {
+	tests := []struct {
+		in   string
+		prec int32
+		want string
+	}{
+		{"0.1(6)", 4, "0.1667"},
+		{"0.(3)", 2, "0.33"},
+		{"0.3̅", 2, "0.33"},
+		{"0.16̅", 4, "0.1667"},
+		{"0.1̅4̅2̅8̅5̅7̅", 12, "0.142857142857"},
+		{"-0.58(3)", 6, "-0.583333"},
+		{"-0.(3)", 3, "-0.333"},
+		{"1.(9)", 2, "2"},
+		{"+12.(12)", 4, "12.1212"},
+		{"2.5", 4, "2.5"},
+		{"42", 0, "42"},
+	}
+	for _, test := range tests {
+		d, err := NewFromRepeatingString(test.in, test.prec)
+		if err != nil {
+			t.Errorf("unexpected error for %s: %v", test.in, err)
+			continue
+		}
+		if d.String() != test.want {
+			t.Errorf("expected %s for %s, got %s", test.want, test.in, d)
+		}
+	}
+
+	for _, in := range []string{
+		"", ".", "0.(3", "0.()", "0.(3a)", "1(3)", "0.(3)4", "0.3̅4", "̅3", "1e5(3)", "NaN", "--1.(3)", "1.2.(3)",
+	} {
+		if d, err := NewFromRepeatingString(in, 2); err == nil {
+			t.Errorf("expected error for %q, got %s", in, d)
+		}
+	}
+
+	// the exact fraction survives a round trip through the string representation
+	for _, s := range []string{"0.(142857)", "-3.1̅4̅2̅8̅5̅7̅", "0.02(27)", "7.25"} {
+		r, err := parseRepeating(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		style := RepeatingParentheses
+		if s[len(s)-1] != ')' {
+			style = RepeatingOverline
+		}
+		if got := r.StringStyle(style); got != s && r.Repetend != "" {
+			t.Errorf("expected %s, got %s", s, got)
+		}
+	}
+}
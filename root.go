@@ -0,0 +1,111 @@
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Sqrt returns the square root of d, rounded half up to precision digits after
+// the decimal point. Negative precision is allowed.
+//
+// The result is correctly rounded, as it's derived from the exact integer square
+// root of the scaled coefficient. Sqrt returns an error if d is negative.
+//
+// Example:
+//
+//	d1, err := NewFromInt(2).Sqrt(10)
+//	d1.String() // output: "1.4142135624"
+//
+//	d2, err := NewFromFloat(0.0144).Sqrt(4)
+//	d2.String() // output: "0.12"
+func (d Decimal) Sqrt(precision int32) (Decimal, error) {
+	return d.Root(2, precision)
+}
+
+// Cbrt returns the cube root of d, rounded half up to precision digits after
+// the decimal point, see Root.
+//
+// Example:
+//
+//	d, err := NewFromInt(-2).Cbrt(6)
+//	d.String() // output: "-1.259921"
+func (d Decimal) Cbrt(precision int32) (Decimal, error) {
+	return d.Root(3, precision)
+}
+
+// Root returns the n-th root of d, rounded half up to precision digits after
+// the decimal point. Negative precision is allowed. Odd roots of negative
+// numbers are negative, rounded like DivRound, away from zero.
+//
+// The result is correctly rounded, as it's derived from the exact integer n-th
+// root of the scaled coefficient computed by Newton's method. Root returns an
+// error if n < 1, or if n is even and d is negative.
+//
+// Example:
+//
+//	d, err := NewFromInt(100).Root(5, 8)
+//	d.String() // output: "2.51188643"
+func (d Decimal) Root(n int, precision int32) (Decimal, error) {
+	if n < 1 {
+		return Decimal{}, fmt.Errorf("cannot calculate root of degree %d", n)
+	}
+	neg := d.Signbit() && !d.IsZero()
+	if neg && n%2 == 0 {
+		return Decimal{}, fmt.Errorf("cannot calculate root of degree %d of negative decimal %s", n, d)
+	}
+	if d.form != formFinite {
+		return d, nil
+	}
+	if precision == math.MinInt32 {
+		return Decimal{}, fmt.Errorf("precision %d overflows the exponent of the root", precision)
+	}
+
+	// with m = floor(|d| * 10^(n * (precision + 1))), the integer root r of m
+	// holds the digits of the root up to one place after the requested
+	// precision, and the root rounded half up is (r + 5) / 10
+	m := new(big.Int).Abs(d.coef())
+	scale := int64(d.exp) + int64(n)*(int64(precision)+1)
+	if scale >= 0 {
+		m.Mul(m, new(big.Int).Exp(tenInt, big.NewInt(scale), nil))
+	} else {
+		m.Quo(m, new(big.Int).Exp(tenInt, big.NewInt(-scale), nil))
+	}
+
+	r := intRoot(m, n)
+	r.Add(r, fiveInt)
+	r.Quo(r, tenInt)
+	if neg {
+		r.Neg(r)
+	}
+	return newDecimal(r, -precision), nil
+}
+
+// intRoot returns the largest integer r with r^n <= m, for m >= 0 and n >= 1.
+func intRoot(m *big.Int, n int) *big.Int {
+	if n == 1 || m.Sign() == 0 {
+		return new(big.Int).Set(m)
+	}
+	if n == 2 {
+		return new(big.Int).Sqrt(m)
+	}
+
+	// Newton's method decreases monotonically towards the root when it starts
+	// above it, here from 2^ceil(bitlen(m) / n) > m^(1/n)
+	bn := big.NewInt(int64(n))
+	bn1 := big.NewInt(int64(n - 1))
+	x := new(big.Int).Lsh(oneInt, uint((m.BitLen()+n-1)/n))
+	var y, p big.Int
+	for {
+		// y = ((n - 1) * x + m / x^(n-1)) / n
+		p.Exp(x, bn1, nil)
+		y.Quo(m, &p)
+		p.Mul(x, bn1)
+		y.Add(&y, &p)
+		y.Quo(&y, bn)
+		if y.Cmp(x) >= 0 {
+			return x
+		}
+		x.Set(&y)
+	}
+}
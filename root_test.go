@@ -0,0 +1,97 @@
+package decimal
+
+import (
+	"testing"
+)
+
+func TestDecimal_Root(t *testing.T) {
+	tests := []struct {
+		input     string
+		n         int
+		precision int32
+		want      string
+	}{
+		{"2", 2, 10, "1.4142135624"},
+		{"2", 2, 50, "1.41421356237309504880168872420969807856967187537695"},
+		{"0.0144", 2, 4, "0.12"},
+		{"1e-7", 2, 5, "0.00032"},
+		{"123456789012345678901234567890", 2, 3, "351364182882014.425"},
+		{"0.5", 2, 0, "1"},
+		{"6.25", 2, 1, "2.5"},
+		{"6.25", 2, 0, "3"},
+		{"2", 2, -1, "0"},
+		{"98765", 2, -2, "300"},
+		{"-2", 3, 6, "-1.259921"},
+		{"27", 3, 0, "3"},
+		{"0.000000001", 3, 3, "0.001"},
+		{"100", 5, 8, "2.51188643"},
+		{"1e300", 7, 5, "7196856730011520199287864249634569392229852.42102"},
+		{"0", 2, 3, "0"},
+		{"-0", 2, 3, "0"},
+		{"42.5", 1, 3, "42.5"},
+	}
+	for _, test := range tests {
+		d := RequireFromString(test.input)
+		got, err := d.Root(test.n, test.precision)
+		if err != nil {
+			t.Errorf("unexpected error for root %d of %s: %v", test.n, d, err)
+			continue
+		}
+		if got.String() != test.want || got.Exponent() != -test.precision {
+			t.Errorf("expected %s for root %d of %s with precision %d, got %s", test.want, test.n, d, test.precision, got)
+		}
+	}
+}
+
+func TestDecimal_SqrtCbrt(t *testing.T) {
+	sqrt, err := NewFromInt(2).Sqrt(10)
+	if err != nil || sqrt.String() != "1.4142135624" {
+		t.Errorf("expected 1.4142135624, got %s and %v", sqrt, err)
+	}
+	cbrt, err := NewFromInt(-2).Cbrt(6)
+	if err != nil || cbrt.String() != "-1.259921" {
+		t.Errorf("expected -1.259921, got %s and %v", cbrt, err)
+	}
+
+	// the root is correctly rounded: squaring the bounds of the rounding
+	// interval brackets the input
+	half := New(5, -21)
+	for _, s := range []string{"2", "3", "0.7", "1234.5678", "99999999999999999999.99"} {
+		d := RequireFromString(s)
+		r, err := d.Sqrt(20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lo, hi := r.Sub(half), r.Add(half)
+		if lo.Mul(lo).Cmp(d) > 0 || hi.Mul(hi).Cmp(d) <= 0 {
+			t.Errorf("square root %s of %s isn't correctly rounded", r, d)
+		}
+	}
+
+	if r, err := Inf(1).Sqrt(2); err != nil || !r.IsInf(1) {
+		t.Errorf("expected Inf, got %s and %v", r, err)
+	}
+	if r, err := Inf(-1).Cbrt(2); err != nil || !r.IsInf(-1) {
+		t.Errorf("expected -Inf, got %s and %v", r, err)
+	}
+	if r, err := NaN().Sqrt(2); err != nil || !r.IsNaN() {
+		t.Errorf("expected NaN, got %s and %v", r, err)
+	}
+}
+
+func TestDecimal_RootErrors(t *testing.T) {
+	for _, test := range []struct {
+		d Decimal
+		n int
+	}{
+		{New(-4, 0), 2},
+		{New(-16, 0), 4},
+		{Inf(-1), 2},
+		{New(4, 0), 0},
+		{New(4, 0), -2},
+	} {
+		if r, err := test.d.Root(test.n, 2); err == nil {
+			t.Errorf("expected error for root %d of %s, got %s", test.n, test.d, r)
+		}
+	}
+}
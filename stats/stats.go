@@ -0,0 +1,300 @@
+// Package stats provides descriptive statistics over slices of decimals.
+//
+// The statistics are computed exactly, and only rounded when they can't be
+// represented as a decimal, half up to an explicit number of places, so that
+// their results are reproducible to the last digit.
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	// ErrNoValues is returned for statistics of an empty slice.
+	ErrNoValues = errors.New("stats: no values")
+
+	// ErrLengthMismatch is returned by WeightedAvg when the numbers of values
+	// and weights differ.
+	ErrLengthMismatch = errors.New("stats: values and weights differ in length")
+)
+
+// Method is the method of Percentile to pick or interpolate a percentile
+// between the two values closest to its rank.
+type Method int
+
+const (
+	// Linear interpolates linearly between the values closest to the rank
+	// p / 100 * (n - 1), like Excel's PERCENTILE.INC and numpy's default.
+	Linear Method = iota
+	// Lower picks the lower value closest to the rank.
+	Lower
+	// Higher picks the higher value closest to the rank.
+	Higher
+	// Nearest picks the value closest to the rank, the even one of two
+	// equally close values.
+	Nearest
+	// Midpoint returns the average of the values closest to the rank.
+	Midpoint
+	// NearestRank picks the value with the rank ceil(p / 100 * n) counted
+	// from 1, and the smallest value for p = 0.
+	NearestRank
+)
+
+// Mean returns the arithmetic mean of values, rounded half up to precision
+// digits after the decimal point. Contrary to decimal.Avg, the precision is
+// explicit and the sum is only rounded once.
+func Mean(values []decimal.Decimal, precision int32) (decimal.Decimal, error) {
+	if len(values) == 0 {
+		return decimal.Decimal{}, ErrNoValues
+	}
+	return sum(values).DivRound(count(values), precision), nil
+}
+
+// Median returns the middle value of the sorted values, or the exact average of
+// the two middle values for an even number of values.
+//
+// Example:
+//
+//	// of 4, 1, 3 and 2
+//	stats.Median(values) // output: "2.5"
+func Median(values []decimal.Decimal) (decimal.Decimal, error) {
+	if len(values) == 0 {
+		return decimal.Decimal{}, ErrNoValues
+	}
+	s := sorted(values)
+	n := len(s)
+	if n%2 == 1 {
+		return s[n/2], nil
+	}
+	return midpoint(s[n/2-1], s[n/2]), nil
+}
+
+// Mode returns the values which occur most often in ascending order. Values
+// are counted as equal when they're numerically equal, such as 1.5 and 1.50,
+// and represented by their first occurrence.
+func Mode(values []decimal.Decimal) ([]decimal.Decimal, error) {
+	if len(values) == 0 {
+		return nil, ErrNoValues
+	}
+	counts := make(map[decimal.Key]int, len(values))
+	first := make(map[decimal.Key]decimal.Decimal, len(values))
+	max := 0
+	for _, v := range values {
+		k := v.Key()
+		if _, ok := first[k]; !ok {
+			first[k] = v
+		}
+		counts[k]++
+		if counts[k] > max {
+			max = counts[k]
+		}
+	}
+
+	var modes []decimal.Decimal
+	for k, c := range counts {
+		if c == max {
+			modes = append(modes, first[k])
+		}
+	}
+	return sorted(modes), nil
+}
+
+// Percentile returns the p-th percentile of values, for p in [0, 100], picked
+// or interpolated with the given method. The result is exact, as the linear
+// interpolation only multiplies by the fraction of the exact rank.
+//
+// Example:
+//
+//	// of 15, 20, 35, 40 and 50
+//	stats.Percentile(values, decimal.NewFromInt(40), stats.Linear)      // output: "29"
+//	stats.Percentile(values, decimal.NewFromInt(40), stats.NearestRank) // output: "20"
+func Percentile(values []decimal.Decimal, p decimal.Decimal, method Method) (decimal.Decimal, error) {
+	if len(values) == 0 {
+		return decimal.Decimal{}, ErrNoValues
+	}
+	if p.IsNaN() || p.Sign() < 0 || p.GreaterThan(decimal.New(100, 0)) {
+		return decimal.Decimal{}, fmt.Errorf("stats: percentile %s is outside [0, 100]", p)
+	}
+	s := sorted(values)
+	n := int64(len(s))
+
+	if method == NearestRank {
+		rank := p.Shift(-2).Mul(decimal.New(n, 0)).Ceil().IntPart()
+		if rank < 1 {
+			rank = 1
+		}
+		return s[rank-1], nil
+	}
+
+	// the rank h = p / 100 * (n - 1) lies between the indexes lo and hi
+	h := p.Shift(-2).Mul(decimal.New(n-1, 0))
+	lo := h.Floor().IntPart()
+	hi := h.Ceil().IntPart()
+	switch method {
+	case Linear:
+		frac := h.Sub(decimal.New(lo, 0))
+		return s[lo].Add(s[hi].Sub(s[lo]).Mul(frac)), nil
+	case Lower:
+		return s[lo], nil
+	case Higher:
+		return s[hi], nil
+	case Nearest:
+		return s[h.RoundBank(0).IntPart()], nil
+	case Midpoint:
+		return midpoint(s[lo], s[hi]), nil
+	}
+	return decimal.Decimal{}, fmt.Errorf("stats: unknown percentile method %d", method)
+}
+
+// Variance returns the population variance of values, the mean of the squared
+// deviations from their mean, rounded half up to precision digits after the
+// decimal point. It's computed exactly before that single rounding.
+func Variance(values []decimal.Decimal, precision int32) (decimal.Decimal, error) {
+	num, den, err := variance(values, false)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return num.DivRound(den, precision), nil
+}
+
+// SampleVariance returns the sample variance of values, with Bessel's
+// correction dividing the squared deviations by n - 1, rounded half up to
+// precision digits after the decimal point. It requires at least two values.
+func SampleVariance(values []decimal.Decimal, precision int32) (decimal.Decimal, error) {
+	num, den, err := variance(values, true)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return num.DivRound(den, precision), nil
+}
+
+// StdDev returns the population standard deviation of values, the square root
+// of their Variance, correctly rounded half up to precision digits after the
+// decimal point. The variance isn't rounded before taking its square root.
+//
+// Example:
+//
+//	// of 2, 4, 4, 4, 5, 5, 7 and 9
+//	stats.StdDev(values, 2) // output: "2"
+func StdDev(values []decimal.Decimal, precision int32) (decimal.Decimal, error) {
+	num, den, err := variance(values, false)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return sqrtQuo(num, den, precision)
+}
+
+// SampleStdDev returns the sample standard deviation of values, the square
+// root of their SampleVariance, correctly rounded half up to precision digits
+// after the decimal point. It requires at least two values.
+func SampleStdDev(values []decimal.Decimal, precision int32) (decimal.Decimal, error) {
+	num, den, err := variance(values, true)
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	return sqrtQuo(num, den, precision)
+}
+
+// WeightedAvg returns the sum of the values multiplied by their weights,
+// divided by the sum of the weights, rounded half up to precision digits after
+// the decimal point. It returns an error if the weights sum up to zero.
+func WeightedAvg(values, weights []decimal.Decimal, precision int32) (decimal.Decimal, error) {
+	if len(values) != len(weights) {
+		return decimal.Decimal{}, ErrLengthMismatch
+	}
+	if len(values) == 0 {
+		return decimal.Decimal{}, ErrNoValues
+	}
+	total, weight := decimal.New(0, 0), decimal.New(0, 0)
+	for i, v := range values {
+		total = total.Add(v.Mul(weights[i]))
+		weight = weight.Add(weights[i])
+	}
+	if weight.IsZero() {
+		return decimal.Decimal{}, errors.New("stats: weights sum up to zero")
+	}
+	return total.DivRound(weight, precision), nil
+}
+
+// GeometricMean returns the n-th root of the product of the n values,
+// correctly rounded half up to precision digits after the decimal point. The
+// product is exact. GeometricMean returns an error for negative values.
+//
+// Example:
+//
+//	// of 1.1, 1.2 and 0.9
+//	stats.GeometricMean(values, 6) // output: "1.059105"
+func GeometricMean(values []decimal.Decimal, precision int32) (decimal.Decimal, error) {
+	if len(values) == 0 {
+		return decimal.Decimal{}, ErrNoValues
+	}
+	product := decimal.New(1, 0)
+	for _, v := range values {
+		if v.Sign() < 0 {
+			return decimal.Decimal{}, fmt.Errorf("stats: cannot calculate geometric mean of negative value %s", v)
+		}
+		product = product.Mul(v)
+	}
+	return product.Root(len(values), precision)
+}
+
+// variance returns the numerator and denominator of the exact variance,
+// n * sum(x^2) - sum(x)^2 over n^2, or over n * (n - 1) for a sample.
+func variance(values []decimal.Decimal, sample bool) (num, den decimal.Decimal, err error) {
+	if len(values) == 0 {
+		return decimal.Decimal{}, decimal.Decimal{}, ErrNoValues
+	}
+	if sample && len(values) < 2 {
+		return decimal.Decimal{}, decimal.Decimal{}, errors.New("stats: sample variance requires at least two values")
+	}
+	squares := decimal.New(0, 0)
+	for _, v := range values {
+		squares = squares.Add(v.Mul(v))
+	}
+	s, n := sum(values), count(values)
+	num = n.Mul(squares).Sub(s.Mul(s))
+	if sample {
+		return num, n.Mul(n.Sub(decimal.New(1, 0))), nil
+	}
+	return num, n.Mul(n), nil
+}
+
+// sqrtQuo returns the square root of num / den, correctly rounded half up to
+// precision places. The square root of the quotient truncated to twice as many
+// places plus two rounds like that of the exact quotient, as the floor of the
+// square root of the floor of a number is the floor of its square root.
+func sqrtQuo(num, den decimal.Decimal, precision int32) (decimal.Decimal, error) {
+	q, _ := num.QuoRem(den, 2*precision+2)
+	return q.Sqrt(precision)
+}
+
+func sum(values []decimal.Decimal) decimal.Decimal {
+	s := decimal.New(0, 0)
+	for _, v := range values {
+		s = s.Add(v)
+	}
+	return s
+}
+
+func count(values []decimal.Decimal) decimal.Decimal {
+	return decimal.New(int64(len(values)), 0)
+}
+
+// midpoint returns the exact average of a and b.
+func midpoint(a, b decimal.Decimal) decimal.Decimal {
+	return a.Add(b).Mul(decimal.New(5, -1))
+}
+
+// sorted returns a sorted copy of values.
+func sorted(values []decimal.Decimal) []decimal.Decimal {
+	s := make([]decimal.Decimal, len(values))
+	copy(s, values)
+	sort.Slice(s, func(i, j int) bool {
+		return s[i].Cmp(s[j]) < 0
+	})
+	return s
+}
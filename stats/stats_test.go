@@ -0,0 +1,164 @@
+package stats
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func decimals(values ...string) []decimal.Decimal {
+	ds := make([]decimal.Decimal, len(values))
+	for i, v := range values {
+		ds[i] = decimal.RequireFromString(v)
+	}
+	return ds
+}
+
+func TestMeanMedianMode(t *testing.T) {
+	values := decimals("1.5", "2.25", "-3", "4", "10.125", "7")
+	if mean, err := Mean(values, 4); err != nil || mean.String() != "3.6458" {
+		t.Errorf("expected mean 3.6458, got %s and %v", mean, err)
+	}
+
+	for _, test := range []struct {
+		values []decimal.Decimal
+		want   string
+	}{
+		{decimals("4", "1", "3", "2"), "2.5"},
+		{decimals("4", "1", "3"), "3"},
+		{decimals("-0.1"), "-0.1"},
+		{decimals("1.1", "1.2"), "1.15"},
+		{values, "3.125"},
+	} {
+		if median, err := Median(test.values); err != nil || median.String() != test.want {
+			t.Errorf("expected median %s of %v, got %s and %v", test.want, test.values, median, err)
+		}
+	}
+
+	for _, test := range []struct {
+		values []decimal.Decimal
+		want   string
+	}{
+		{decimals("1", "2", "2", "3"), "[2]"},
+		{decimals("1.5", "3", "1.50", "3.0", "7"), "[1.5 3]"},
+		{decimals("5", "4", "3"), "[3 4 5]"},
+	} {
+		modes, err := Mode(test.values)
+		if got := fmt.Sprint(modes); err != nil || got != test.want {
+			t.Errorf("expected modes %s of %v, got %s and %v", test.want, test.values, got, err)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	values := decimals("50", "15", "40", "20", "35")
+	methods := []Method{Linear, Lower, Higher, Nearest, Midpoint, NearestRank}
+	for _, test := range []struct {
+		p    string
+		want []string
+	}{
+		{"0", []string{"15", "15", "15", "15", "15", "15"}},
+		{"25", []string{"20", "20", "20", "20", "20", "20"}},
+		{"40", []string{"29", "20", "35", "35", "27.5", "20"}},
+		{"62.5", []string{"37.5", "35", "40", "35", "37.5", "40"}},
+		{"100", []string{"50", "50", "50", "50", "50", "50"}},
+		{"33.3", []string{"24.98", "20", "35", "20", "27.5", "20"}},
+	} {
+		p := decimal.RequireFromString(test.p)
+		for i, method := range methods {
+			got, err := Percentile(values, p, method)
+			if err != nil || got.String() != test.want[i] {
+				t.Errorf("expected percentile %s with method %d to be %s, got %s and %v", p, method, test.want[i], got, err)
+			}
+		}
+	}
+
+	for _, p := range decimals("-1", "100.01") {
+		if _, err := Percentile(values, p, Linear); err == nil {
+			t.Errorf("expected error for percentile %s", p)
+		}
+	}
+	if _, err := Percentile(values, decimal.New(5, 0), Method(42)); err == nil {
+		t.Errorf("expected error for unknown method")
+	}
+}
+
+func TestVarianceStdDev(t *testing.T) {
+	values := decimals("1.5", "2.25", "-3", "4", "10.125", "7")
+	tests := []struct {
+		f    func([]decimal.Decimal, int32) (decimal.Decimal, error)
+		name string
+		prec int32
+		want string
+	}{
+		{Variance, "variance", 6, "17.34592"},
+		{SampleVariance, "sample variance", 6, "20.815104"},
+		{StdDev, "standard deviation", 10, "4.1648433511"},
+		{SampleStdDev, "sample standard deviation", 10, "4.5623573037"},
+	}
+	for _, test := range tests {
+		got, err := test.f(values, test.prec)
+		if err != nil || got.String() != test.want {
+			t.Errorf("expected %s %s, got %s and %v", test.name, test.want, got, err)
+		}
+	}
+
+	if sd, err := StdDev(decimals("2", "4", "4", "4", "5", "5", "7", "9"), 2); err != nil || sd.String() != "2" {
+		t.Errorf("expected standard deviation 2, got %s and %v", sd, err)
+	}
+	if _, err := SampleVariance(decimals("1"), 2); err == nil {
+		t.Errorf("expected error for the sample variance of a single value")
+	}
+	if v, err := Variance(decimals("3"), 2); err != nil || !v.IsZero() {
+		t.Errorf("expected zero variance, got %s and %v", v, err)
+	}
+}
+
+func TestWeightedAvgGeometricMean(t *testing.T) {
+	avg, err := WeightedAvg(decimals("10", "20", "40"), decimals("1", "2", "3"), 5)
+	if err != nil || avg.String() != "28.33333" {
+		t.Errorf("expected 28.33333, got %s and %v", avg, err)
+	}
+	if _, err := WeightedAvg(decimals("10", "20"), decimals("1"), 5); err != ErrLengthMismatch {
+		t.Errorf("expected ErrLengthMismatch, got %v", err)
+	}
+	if _, err := WeightedAvg(decimals("10", "20"), decimals("1", "-1"), 5); err == nil {
+		t.Errorf("expected error for weights summing up to zero")
+	}
+
+	gm, err := GeometricMean(decimals("1.1", "1.2", "0.9"), 6)
+	if err != nil || gm.String() != "1.059105" {
+		t.Errorf("expected 1.059105, got %s and %v", gm, err)
+	}
+	if gm, err := GeometricMean(decimals("4", "0"), 2); err != nil || !gm.IsZero() {
+		t.Errorf("expected 0, got %s and %v", gm, err)
+	}
+	if _, err := GeometricMean(decimals("4", "-1"), 2); err == nil {
+		t.Errorf("expected error for a negative value")
+	}
+}
+
+func TestNoValues(t *testing.T) {
+	var empty []decimal.Decimal
+	errs := []error{}
+	_, err := Mean(empty, 2)
+	errs = append(errs, err)
+	_, err = Median(empty)
+	errs = append(errs, err)
+	_, err = Mode(empty)
+	errs = append(errs, err)
+	_, err = Percentile(empty, decimal.New(50, 0), Linear)
+	errs = append(errs, err)
+	_, err = StdDev(empty, 2)
+	errs = append(errs, err)
+	_, err = WeightedAvg(empty, empty, 2)
+	errs = append(errs, err)
+	_, err = GeometricMean(empty, 2)
+	errs = append(errs, err)
+	for i, err := range errs {
+		if err != ErrNoValues {
+			t.Errorf("expected ErrNoValues for statistic %d, got %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,400 @@
+package decimal
+
+import (
+	"fmt"
+)
+
+// SinPrec returns the sine of the radian argument d, correctly rounded half up
+// to precision digits after the decimal point. Negative precision is allowed.
+//
+// Contrary to Sin, SinPrec computes a Taylor series to the requested precision.
+// The argument is reduced modulo Pi/2 with as many digits of Pi as it has
+// integer digits, so that the result of a huge argument stays accurate.
+// SinPrec returns NaN for NaN and infinities, and an error for arguments with
+// more than 100000 integer digits.
+//
+// Example:
+//
+//	d1, err := NewFromInt(1).SinPrec(30)
+//	d1.String() // output: "0.84147098480789650665250232163"
+//
+//	d2, err := New(1, 22).SinPrec(10)
+//	d2.String() // output: "-0.8522008498"
+func (d Decimal) SinPrec(precision int32) (Decimal, error) {
+	if d.form != formFinite {
+		return NaN(), nil
+	}
+	if d.IsZero() {
+		return New(0, 0).Round(precision), nil
+	}
+	return roundApprox(precision, func(places int32) (Decimal, error) {
+		sin, _, err := d.sinCos(places)
+		return sin, err
+	})
+}
+
+// CosPrec returns the cosine of the radian argument d, correctly rounded half
+// up to precision digits after the decimal point, see SinPrec.
+//
+// Example:
+//
+//	d, err := NewFromInt(1).CosPrec(30)
+//	d.String() // output: "0.540302305868139717400936607443"
+func (d Decimal) CosPrec(precision int32) (Decimal, error) {
+	if d.form != formFinite {
+		return NaN(), nil
+	}
+	return roundApprox(precision, func(places int32) (Decimal, error) {
+		_, cos, err := d.sinCos(places)
+		return cos, err
+	})
+}
+
+// TanPrec returns the tangent of the radian argument d, correctly rounded half
+// up to precision digits after the decimal point, see SinPrec. Close to the
+// poles of the tangent, the sine and cosine are computed with more digits to
+// keep the quotient accurate.
+//
+// Example:
+//
+//	d, err := NewFromInt(1).TanPrec(30)
+//	d.String() // output: "1.557407724654902230506974807458"
+func (d Decimal) TanPrec(precision int32) (Decimal, error) {
+	if d.form != formFinite {
+		return NaN(), nil
+	}
+	if d.IsZero() {
+		return New(0, 0).Round(precision), nil
+	}
+	return roundApprox(precision, func(places int32) (Decimal, error) {
+		// the error of sin / cos is about err / cos^2, so that a cosine with
+		// the adjusted exponent a < 0 requires -2a extra places
+		extra := int32(0)
+		for {
+			sin, cos, err := d.sinCos(places + extra)
+			if err != nil {
+				return Decimal{}, err
+			}
+			if cos.IsZero() {
+				extra = 2*extra + places + 2
+				continue
+			}
+			need := int32(0)
+			if a := cos.adjusted(); a < 0 {
+				need = -2*a + 1
+			}
+			if need <= extra {
+				return sin.DivRound(cos, places+1), nil
+			}
+			extra = need
+		}
+	})
+}
+
+// AtanPrec returns the arctangent, in radians, of d, correctly rounded half up
+// to precision digits after the decimal point. Negative precision is allowed.
+// AtanPrec(±Inf) is ±Pi/2.
+//
+// Contrary to Atan, AtanPrec computes a series to the requested precision after
+// reducing the argument below 0.1 with the identities
+// atan(x) = Pi/2 - atan(1/x) and atan(x) = 2 * atan(x / (1 + sqrt(1 + x^2))).
+//
+// Example:
+//
+//	d, err := NewFromInt(1).AtanPrec(30)
+//	d.String() // output: "0.78539816339744830961566084582"
+func (d Decimal) AtanPrec(precision int32) (Decimal, error) {
+	if d.IsNaN() {
+		return NaN(), nil
+	}
+	if d.form == formFinite && d.IsZero() {
+		return New(0, 0).Round(precision), nil
+	}
+	return roundApprox(precision, func(places int32) (Decimal, error) {
+		r, err := d.Abs().atanAbs(places)
+		if err != nil {
+			return Decimal{}, err
+		}
+		if d.Signbit() {
+			r = r.Neg()
+		}
+		return r, nil
+	})
+}
+
+// Asin returns the arcsine, in radians, of d, rounded half up to
+// DivisionPrecision digits after the decimal point, see AsinPrec.
+func (d Decimal) Asin() (Decimal, error) {
+	return d.AsinPrec(int32(DivisionPrecision))
+}
+
+// AsinPrec returns the arcsine, in radians, of d, correctly rounded half up to
+// precision digits after the decimal point. Negative precision is allowed.
+//
+// AsinPrec returns an error if d is outside [-1, 1], and NaN for NaN.
+//
+// Example:
+//
+//	d, err := NewFromFloat(0.5).AsinPrec(30)
+//	d.String() // output: "0.523598775598298873077107230547"
+func (d Decimal) AsinPrec(precision int32) (Decimal, error) {
+	if d.IsNaN() {
+		return NaN(), nil
+	}
+	s, ok := d.cosAsin()
+	if !ok {
+		return Decimal{}, fmt.Errorf("cannot calculate arcsine of %s outside [-1, 1]", d)
+	}
+
+	// asin(d) = atan2(d, sqrt(1 - d^2))
+	x := d.Abs()
+	return roundApprox(precision, func(places int32) (Decimal, error) {
+		c, err := s.Sqrt(places + 4)
+		if err != nil {
+			return Decimal{}, err
+		}
+		r, err := atan2Abs(x, c, places)
+		if err != nil {
+			return Decimal{}, err
+		}
+		if d.Signbit() {
+			r = r.Neg()
+		}
+		return r, nil
+	})
+}
+
+// Acos returns the arccosine, in radians, of d, rounded half up to
+// DivisionPrecision digits after the decimal point, see AcosPrec.
+func (d Decimal) Acos() (Decimal, error) {
+	return d.AcosPrec(int32(DivisionPrecision))
+}
+
+// AcosPrec returns the arccosine, in radians, of d, correctly rounded half up
+// to precision digits after the decimal point. Negative precision is allowed.
+//
+// AcosPrec returns an error if d is outside [-1, 1], and NaN for NaN.
+//
+// Example:
+//
+//	d, err := NewFromFloat(-0.5).AcosPrec(30)
+//	d.String() // output: "2.094395102393195492308428922186"
+func (d Decimal) AcosPrec(precision int32) (Decimal, error) {
+	if d.IsNaN() {
+		return NaN(), nil
+	}
+	s, ok := d.cosAsin()
+	if !ok {
+		return Decimal{}, fmt.Errorf("cannot calculate arccosine of %s outside [-1, 1]", d)
+	}
+
+	// acos(d) = atan2(sqrt(1 - d^2), d)
+	x := d.Abs()
+	return roundApprox(precision, func(places int32) (Decimal, error) {
+		c, err := s.Sqrt(places + 4)
+		if err != nil {
+			return Decimal{}, err
+		}
+		r, err := atan2Abs(c, x, places)
+		if err != nil || !d.Signbit() {
+			return r, err
+		}
+		return pi.withPrecision(places + 1).Sub(r), nil
+	})
+}
+
+// Atan2 returns the arctangent, in radians, of d / x, using the signs of both
+// to determine the quadrant of the result, rounded half up to
+// DivisionPrecision digits after the decimal point, see Atan2Prec.
+func (d Decimal) Atan2(x Decimal) (Decimal, error) {
+	return d.Atan2Prec(x, int32(DivisionPrecision))
+}
+
+// Atan2Prec returns the arctangent, in radians, of d / x, using the signs of
+// both to determine the quadrant of the result, correctly rounded half up to
+// precision digits after the decimal point. The result is in [-Pi, Pi].
+//
+// Like math.Atan2, Atan2Prec returns NaN if d or x is NaN, handles the
+// infinities as limits and uses the sign of zeros, so that the arctangent of
+// ±0 / -0 is ±Pi, and that of ±0 / 0 is 0.
+//
+// Example:
+//
+//	d, err := NewFromInt(1).Atan2Prec(NewFromInt(-1), 20)
+//	d.String() // output: "2.35619449019234492885"
+func (d Decimal) Atan2Prec(x Decimal, precision int32) (Decimal, error) {
+	if d.IsNaN() || x.IsNaN() {
+		return NaN(), nil
+	}
+
+	// the angle of (|d|, |x|) is the same as that of finite substitutes for
+	// infinities and the zero vector
+	ay, ax := d.Abs(), x.Abs()
+	switch {
+	case ay.form == formInfinite && ax.form == formInfinite:
+		ay, ax = New(1, 0), New(1, 0)
+	case ay.form == formInfinite:
+		ay, ax = New(1, 0), New(0, 0)
+	case ax.form == formInfinite:
+		ay, ax = New(0, 0), New(1, 0)
+	case ay.IsZero() && ax.IsZero():
+		ax = New(1, 0)
+	}
+
+	return roundApprox(precision, func(places int32) (Decimal, error) {
+		r, err := atan2Abs(ay, ax, places)
+		if err != nil {
+			return Decimal{}, err
+		}
+		if x.Signbit() {
+			r = pi.withPrecision(places + 1).Sub(r)
+		}
+		if d.Signbit() {
+			r = r.Neg()
+		}
+		return r, nil
+	})
+}
+
+// maxReduceDigits is the maximum number of integer digits of the arguments of
+// SinPrec, CosPrec and TanPrec, whose reduction needs as many digits of Pi.
+const maxReduceDigits = 100000
+
+// sinCos returns the sine and cosine of d with an error of about a unit in the
+// given number of places.
+func (d Decimal) sinCos(places int32) (sin, cos Decimal, err error) {
+	w := places + 3
+	r, q, err := d.Abs().reduceHalfPi(w)
+	if err != nil {
+		return Decimal{}, Decimal{}, err
+	}
+
+	// with |d| = r + q * Pi/2, the quadrant q mod 4 exchanges and negates
+	// the sine and cosine of r
+	sin, cos = sinCosSeries(r, w)
+	switch q {
+	case 1:
+		sin, cos = cos, sin.Neg()
+	case 2:
+		sin, cos = sin.Neg(), cos.Neg()
+	case 3:
+		sin, cos = cos.Neg(), sin
+	}
+	if d.Signbit() {
+		sin = sin.Neg()
+	}
+	return sin, cos, nil
+}
+
+// reduceHalfPi returns r = d - q * Pi/2 with |r| <= Pi/4 about, and q mod 4,
+// for d >= 0. The error of r is less than a unit in the given number of
+// places, as Pi is taken with as many more places as q has digits.
+func (d Decimal) reduceHalfPi(places int32) (Decimal, int64, error) {
+	if d.Cmp(New(78, -2)) < 0 {
+		return d.Round(places + 1), 0, nil
+	}
+
+	adj := int64(d.exp) + int64(d.NumDigits()) - 1
+	if adj >= maxReduceDigits {
+		return Decimal{}, 0, fmt.Errorf("cannot reduce argument %s with more than %d integer digits", d, maxReduceDigits)
+	}
+	halfPi := pi.withPrecision(places + int32(adj) + 2).Mul(New(5, -1))
+	q := d.DivRound(halfPi, 0)
+	r := d.Sub(q.Mul(halfPi)).Round(places + 1)
+	return r, q.Mod(New(4, 0)).IntPart(), nil
+}
+
+// sinCosSeries returns the sine and cosine of x, for |x| <= 1, by their Taylor
+// series with the terms rounded to w + 2 places.
+func sinCosSeries(x Decimal, w int32) (sin, cos Decimal) {
+	epsilon := New(1, -w-1)
+	sin, cos = New(0, 0), New(1, 0)
+	term := New(1, 0)
+	for k := int64(1); term.Abs().Cmp(epsilon) >= 0; k++ {
+		// term is x^k / k!, subtracted for k = 2, 3 mod 4
+		term = term.Mul(x).DivRound(New(k, 0), w+2)
+		t := term
+		if k%4 == 2 || k%4 == 3 {
+			t = t.Neg()
+		}
+		if k%2 == 1 {
+			sin = sin.Add(t)
+		} else {
+			cos = cos.Add(t)
+		}
+	}
+	return sin, cos
+}
+
+// atanAbs returns the arctangent of d >= 0 with an error of about a unit in the
+// given number of places.
+func (d Decimal) atanAbs(places int32) (Decimal, error) {
+	// each halving of the argument doubles the error of its arctangent,
+	// which is covered by the extra places as there are at most 3 of them
+	w := places + 4
+	one := New(1, 0)
+	var p Decimal
+	x := d
+	if d.form != formFinite || d.Cmp(one) > 0 {
+		p = pi.withPrecision(w + 1)
+		if d.form != formFinite {
+			return p.Mul(New(5, -1)), nil
+		}
+		x = one.DivRound(d, w+2)
+	} else {
+		x = x.Round(w + 2)
+	}
+
+	k := int32(0)
+	for limit := New(1, -1); x.Cmp(limit) > 0; k++ {
+		s, err := x.Mul(x).Add(one).Sqrt(w + 2)
+		if err != nil {
+			return Decimal{}, err
+		}
+		x = x.DivRound(s.Add(one), w+2)
+	}
+
+	// atan(x) = x - x^3/3 + x^5/5 - ...
+	epsilon := New(1, -w-1)
+	x2 := x.Mul(x).Round(w + 2)
+	sum, pow := x, x
+	for n := int64(3); ; n += 2 {
+		pow = pow.Mul(x2).Round(w + 2).Neg()
+		term := pow.DivRound(New(n, 0), w+2)
+		sum = sum.Add(term)
+		if term.Abs().Cmp(epsilon) < 0 {
+			break
+		}
+	}
+	for ; k > 0; k-- {
+		sum = sum.Add(sum)
+	}
+
+	if d.Cmp(one) > 0 {
+		sum = p.Mul(New(5, -1)).Sub(sum)
+	}
+	return sum, nil
+}
+
+// atan2Abs returns the arctangent of y / x, for finite y, x >= 0 which aren't
+// both zero, with an error of about a unit in the given number of places.
+func atan2Abs(y, x Decimal, places int32) (Decimal, error) {
+	if y.Cmp(x) <= 0 {
+		return y.DivRound(x, places+6).atanAbs(places)
+	}
+	// the quotient y / x would lose the digits of x if x is small
+	r, err := x.DivRound(y, places+6).atanAbs(places)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return pi.withPrecision(places + 1).Mul(New(5, -1)).Sub(r), nil
+}
+
+// cosAsin returns 1 - d^2, the square of the cosine of asin(d), and whether d
+// is in [-1, 1].
+func (d Decimal) cosAsin() (Decimal, bool) {
+	if d.form != formFinite || d.Abs().Cmp(New(1, 0)) > 0 {
+		return Decimal{}, false
+	}
+	return New(1, 0).Sub(d.Mul(d)), true
+}
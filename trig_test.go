@@ -0,0 +1,182 @@
+package decimal
+
+import (
+	"testing"
+)
+
+func TestDecimal_TrigPrec(t *testing.T) {
+	fns := map[string]func(Decimal, int32) (Decimal, error){
+		"sin":  Decimal.SinPrec,
+		"cos":  Decimal.CosPrec,
+		"tan":  Decimal.TanPrec,
+		"atan": Decimal.AtanPrec,
+	}
+	tests := []struct {
+		fn, input string
+		precision int32
+		want      string
+	}{
+		{"sin", "1", 30, "0.841470984807896506652502321630"},
+		{"sin", "-2.5", 20, "-0.59847214410395649405"},
+		{"sin", "10000000000000000000000", 10, "-0.8522008498"},
+		{"sin", "1e300", 15, "-0.985750425160377"},
+		{"sin", "3.14159265358979", 25, "0.0000000000000032384626434"},
+		{"sin", "355", 20, "-0.00003014435335948845"},
+		{"sin", "0.5", 0, "0"},
+		{"sin", "0.00000000000000000001", 25, "0.0000000000000000000100000"},
+		{"sin", "123456789.123456789", 12, "0.999850930872"},
+		{"sin", "0.7853981633974483", 40, "0.7071067811865475176015453724356416533979"},
+		{"sin", "0", 3, "0.000"},
+		{"sin", "1e3500", 10, "-0.999906741"},
+		{"cos", "1", 30, "0.540302305868139717400936607443"},
+		{"cos", "10000000000000000000000", 10, "0.5232147854"},
+		{"cos", "1e300", 15, "-0.168214444374245"},
+		{"cos", "1.5707963267948966", 30, "0.000000000000000019231321691640"},
+		{"cos", "0", 5, "1.00000"},
+		{"cos", "1e3500", 10, "-0.013656839"},
+		{"cos", "-2.5", 20, "-0.80114361554693371483"},
+		{"cos", "100", 3, "0.862"},
+		{"cos", "0.00000000000000000001", 25, "1.0000000000000000000000000"},
+		{"tan", "1", 30, "1.557407724654902230506974807458"},
+		{"tan", "-3", 20, "0.14254654307427780530"},
+		{"tan", "1.5707963267948966", 10, "51998506188720270.6601947417"},
+		{"tan", "11", 15, "-225.950846454195142"},
+		{"tan", "10000000000000000000000", 10, "-1.6287782256"},
+		{"tan", "0.5", -1, "0"},
+		{"tan", "1e-30", 35, "0.00000000000000000000000000000100000"},
+		{"atan", "1", 30, "0.785398163397448309615660845820"},
+		{"atan", "-0.5", 25, "-0.4636476090008061162142562"},
+		{"atan", "10", 20, "1.47112767430373459185"},
+		{"atan", "1e30", 10, "1.5707963268"},
+		{"atan", "0.0000000001", 25, "0.0000000001000000000000000"},
+		{"atan", "0.1", 30, "0.099668652491162027378446119878"},
+		{"atan", "123456.789", 15, "1.570788226794823"},
+		{"atan", "2", 50, "1.10714871779409050301706546017853704007004764540143"},
+		{"atan", "0.99999", 20, "0.78539316337244822628"},
+	}
+	for _, test := range tests {
+		d := RequireFromString(test.input)
+		got, err := fns[test.fn](d, test.precision)
+		if err != nil || !got.Equal(RequireFromString(test.want)) {
+			t.Errorf("expected %s(%s) = %s, got %s and %v", test.fn, d, test.want, got, err)
+		}
+	}
+}
+
+func TestDecimal_TrigPrecSpecial(t *testing.T) {
+	for _, d := range []Decimal{NaN(), Inf(1), Inf(-1)} {
+		for _, f := range []func(Decimal, int32) (Decimal, error){Decimal.SinPrec, Decimal.CosPrec, Decimal.TanPrec} {
+			if r, err := f(d, 5); err != nil || !r.IsNaN() {
+				t.Errorf("expected NaN for %s, got %s and %v", d, r, err)
+			}
+		}
+	}
+	if r, err := Inf(-1).AtanPrec(10); err != nil || r.String() != "-1.5707963268" {
+		t.Errorf("expected -1.5707963268, got %s and %v", r, err)
+	}
+	if r, err := NaN().AtanPrec(10); err != nil || !r.IsNaN() {
+		t.Errorf("expected NaN, got %s and %v", r, err)
+	}
+
+	if _, err := New(1, maxReduceDigits).SinPrec(2); err == nil {
+		t.Errorf("expected error for sin(1e%d)", maxReduceDigits)
+	}
+}
+
+func TestDecimal_AsinAcos(t *testing.T) {
+	fns := map[string]func(Decimal, int32) (Decimal, error){
+		"asin": Decimal.AsinPrec,
+		"acos": Decimal.AcosPrec,
+	}
+	tests := []struct {
+		fn, input string
+		precision int32
+		want      string
+	}{
+		{"asin", "0.5", 30, "0.523598775598298873077107230547"},
+		{"asin", "-1", 20, "-1.57079632679489661923"},
+		{"asin", "0.99999999999", 25, "1.5707918546589416159251489"},
+		{"asin", "1e-20", 25, "0.0000000000000000000100000"},
+		{"asin", "-0.7071", 15, "-0.785388573397448"},
+		{"asin", "0.123456789", 40, "0.1237725724267170793121297187292682796567"},
+		{"acos", "-0.5", 30, "2.094395102393195492308428922186"},
+		{"acos", "1", 10, "0.0000000000"},
+		{"acos", "-1", 10, "3.1415926536"},
+		{"acos", "0.99999999999", 25, "0.0000044721359550033061728"},
+		{"acos", "0", 20, "1.57079632679489661923"},
+		{"acos", "0.3", 12, "1.266103672779"},
+	}
+	for _, test := range tests {
+		d := RequireFromString(test.input)
+		got, err := fns[test.fn](d, test.precision)
+		if err != nil || !got.Equal(RequireFromString(test.want)) {
+			t.Errorf("expected %s(%s) = %s, got %s and %v", test.fn, d, test.want, got, err)
+		}
+	}
+
+	for _, d := range []Decimal{RequireFromString("1.0000000001"), New(-2, 0), Inf(1), Inf(-1)} {
+		if r, err := d.Asin(); err == nil {
+			t.Errorf("expected error for asin(%s), got %s", d, r)
+		}
+		if r, err := d.Acos(); err == nil {
+			t.Errorf("expected error for acos(%s), got %s", d, r)
+		}
+	}
+	if r, err := NaN().Asin(); err != nil || !r.IsNaN() {
+		t.Errorf("expected NaN, got %s and %v", r, err)
+	}
+	if r, err := NewFromFloat(0.5).Acos(); err != nil || r.String() != "1.0471975511965977" {
+		t.Errorf("expected 1.0471975511965977, got %s and %v", r, err)
+	}
+}
+
+func TestDecimal_Atan2(t *testing.T) {
+	tests := []struct {
+		y, x      string
+		precision int32
+		want      string
+	}{
+		{"1", "-1", 20, "2.35619449019234492885"},
+		{"-1", "-1", 20, "-2.35619449019234492885"},
+		{"3", "4", 25, "0.6435011087932843868028092"},
+		{"-3", "4", 25, "-0.6435011087932843868028092"},
+		{"1e-30", "-1", 35, "3.14159265358979323846264338327850288"},
+		{"1e30", "1", 10, "1.5707963268"},
+		{"0", "-2", 15, "3.141592653589793"},
+		{"5", "0", 10, "1.5707963268"},
+		{"-5", "0", 10, "-1.5707963268"},
+		{"2", "-1e-10", 20, "1.57079632684489661923"},
+	}
+	for _, test := range tests {
+		y, x := RequireFromString(test.y), RequireFromString(test.x)
+		got, err := y.Atan2Prec(x, test.precision)
+		if err != nil || !got.Equal(RequireFromString(test.want)) {
+			t.Errorf("expected atan2(%s, %s) = %s, got %s and %v", y, x, test.want, got, err)
+		}
+	}
+
+	negZero := RequireFromString("-0")
+	special := []struct {
+		y, x Decimal
+		want string
+	}{
+		{Inf(1), Inf(1), "0.7853981634"},
+		{Inf(1), Inf(-1), "2.3561944902"},
+		{Inf(-1), New(5, 0), "-1.5707963268"},
+		{New(5, 0), Inf(1), "0"},
+		{New(-5, 0), Inf(-1), "-3.1415926536"},
+		{New(0, 0), New(0, 0), "0"},
+		{New(0, 0), negZero, "3.1415926536"},
+		{negZero, negZero, "-3.1415926536"},
+		{New(0, 0), New(-1, 0), "3.1415926536"},
+	}
+	for _, test := range special {
+		got, err := test.y.Atan2Prec(test.x, 10)
+		if err != nil || got.String() != test.want {
+			t.Errorf("expected atan2(%s, %s) = %s, got %s and %v", test.y, test.x, test.want, got, err)
+		}
+	}
+	if r, err := NaN().Atan2(New(1, 0)); err != nil || !r.IsNaN() {
+		t.Errorf("expected NaN, got %s and %v", r, err)
+	}
+}